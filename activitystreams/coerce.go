@@ -0,0 +1,333 @@
+package activitystreams
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mmcdole/gofeed/internal/shared"
+)
+
+// ActivityStreams is JSON-LD, which lets almost any property be a string, an
+// object or an array of either: an actor may be its IRI or the embedded
+// actor, a url a string or a Link, and a single attachment need not be
+// wrapped in an array. These Unmarshalers flatten those forms into the plain
+// fields of the structs.
+//
+// Each uses the standard alias trick: a shadow type without methods decodes
+// every field normally, while the polymorphic fields are pulled out as raw
+// JSON and reduced by hand.
+
+// objectTypes are the object types read as an object when they appear
+// bare in a collection rather than wrapped in an activity.
+var objectTypes = map[string]bool{
+	"Article":  true,
+	"Audio":    true,
+	"Document": true,
+	"Event":    true,
+	"Image":    true,
+	"Note":     true,
+	"Page":     true,
+	"Question": true,
+	"Video":    true,
+}
+
+func (c *Collection) UnmarshalJSON(data []byte) error {
+	type alias Collection
+	aux := &struct {
+		TotalItems   json.RawMessage `json:"totalItems"`
+		First        json.RawMessage `json:"first"`
+		Last         json.RawMessage `json:"last"`
+		Next         json.RawMessage `json:"next"`
+		Prev         json.RawMessage `json:"prev"`
+		PartOf       json.RawMessage `json:"partOf"`
+		Items        json.RawMessage `json:"items"`
+		OrderedItems json.RawMessage `json:"orderedItems"`
+		*alias
+	}{alias: (*alias)(c)}
+	if err := json.Unmarshal(data, aux); err != nil {
+		return err
+	}
+	c.TotalItems = coerceInt(aux.TotalItems)
+	c.Last = reference(aux.Last, "id")
+	c.Next = reference(aux.Next, "id")
+	c.Prev = reference(aux.Prev, "id")
+	c.PartOf = reference(aux.PartOf, "id")
+
+	c.First = reference(aux.First, "id")
+	if bytes.HasPrefix(bytes.TrimSpace(aux.First), []byte("{")) {
+		c.FirstPage = &Collection{}
+		if err := json.Unmarshal(aux.First, c.FirstPage); err != nil {
+			return err
+		}
+	}
+
+	items := aux.OrderedItems
+	if isEmptyJSON(items) {
+		items = aux.Items
+	}
+	c.Items = nil
+	for _, raw := range elements(items) {
+		activity := &Activity{}
+		if err := json.Unmarshal(raw, activity); err != nil {
+			return err
+		}
+		c.Items = append(c.Items, activity)
+	}
+	return nil
+}
+
+func (a *Activity) UnmarshalJSON(data []byte) error {
+	// An item given only as its IRI.
+	var iri string
+	if err := json.Unmarshal(data, &iri); err == nil {
+		*a = Activity{ID: iri}
+		return nil
+	}
+
+	type alias Activity
+	aux := &struct {
+		Actor  json.RawMessage `json:"actor"`
+		To     json.RawMessage `json:"to"`
+		CC     json.RawMessage `json:"cc"`
+		Object json.RawMessage `json:"object"`
+		*alias
+	}{alias: (*alias)(a)}
+	if err := json.Unmarshal(data, aux); err != nil {
+		return err
+	}
+	a.Actor = reference(aux.Actor, "id")
+	a.To = references(aux.To, "id")
+	a.CC = references(aux.CC, "id")
+	a.PublishedParsed = parseDateUTC(a.Published)
+
+	switch {
+	case !isEmptyJSON(aux.Object):
+		a.Object = &Object{}
+		return json.Unmarshal(aux.Object, a.Object)
+	case objectTypes[a.Type]:
+		a.Object = &Object{}
+		return json.Unmarshal(data, a.Object)
+	}
+	a.Object = nil
+	return nil
+}
+
+func (o *Object) UnmarshalJSON(data []byte) error {
+	// An object given only as its IRI, as in most Announce activities.
+	var iri string
+	if err := json.Unmarshal(data, &iri); err == nil {
+		*o = Object{ID: iri}
+		return nil
+	}
+	// Of several objects, the first is kept.
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("[")) {
+		if elems := elements(data); len(elems) > 0 {
+			return json.Unmarshal(elems[0], o)
+		}
+		return nil
+	}
+
+	type alias Object
+	aux := &struct {
+		URL          json.RawMessage   `json:"url"`
+		AttributedTo json.RawMessage   `json:"attributedTo"`
+		InReplyTo    json.RawMessage   `json:"inReplyTo"`
+		Sensitive    json.RawMessage   `json:"sensitive"`
+		To           json.RawMessage   `json:"to"`
+		CC           json.RawMessage   `json:"cc"`
+		Attachments  json.RawMessage   `json:"attachment"`
+		Tags         json.RawMessage   `json:"tag"`
+		NameMap      map[string]string `json:"nameMap"`
+		SummaryMap   map[string]string `json:"summaryMap"`
+		ContentMap   map[string]string `json:"contentMap"`
+		*alias
+	}{alias: (*alias)(o)}
+	if err := json.Unmarshal(data, aux); err != nil {
+		return err
+	}
+	o.URL = reference(aux.URL, "href")
+	o.AttributedTo = reference(aux.AttributedTo, "id")
+	o.InReplyTo = reference(aux.InReplyTo, "id")
+	o.To = references(aux.To, "id")
+	o.CC = references(aux.CC, "id")
+	json.Unmarshal(aux.Sensitive, &o.Sensitive)
+
+	// The natural language maps stand in for the plain properties when
+	// those are missing.
+	if o.Name == "" {
+		o.Name = firstValue(aux.NameMap)
+	}
+	if o.Summary == "" {
+		o.Summary = firstValue(aux.SummaryMap)
+	}
+	if o.Content == "" {
+		o.Content = firstValue(aux.ContentMap)
+	}
+
+	o.PublishedParsed = parseDateUTC(o.Published)
+	o.UpdatedParsed = parseDateUTC(o.Updated)
+
+	o.Attachments = nil
+	for _, raw := range elements(aux.Attachments) {
+		attachment := &Attachment{}
+		if err := json.Unmarshal(raw, attachment); err != nil {
+			return err
+		}
+		o.Attachments = append(o.Attachments, attachment)
+	}
+	o.Tags = nil
+	for _, raw := range elements(aux.Tags) {
+		tag := &Tag{}
+		if err := json.Unmarshal(raw, tag); err != nil {
+			return err
+		}
+		o.Tags = append(o.Tags, tag)
+	}
+	return nil
+}
+
+func (a *Attachment) UnmarshalJSON(data []byte) error {
+	type alias Attachment
+	aux := &struct {
+		URL    json.RawMessage `json:"url"`
+		Width  json.RawMessage `json:"width"`
+		Height json.RawMessage `json:"height"`
+		*alias
+	}{alias: (*alias)(a)}
+	if err := json.Unmarshal(data, aux); err != nil {
+		return err
+	}
+	a.URL = reference(aux.URL, "href")
+	a.Width = coerceInt(aux.Width)
+	a.Height = coerceInt(aux.Height)
+
+	// A url given as a Link may carry the media type instead.
+	if a.MediaType == "" {
+		var link struct {
+			MediaType string `json:"mediaType"`
+		}
+		if elems := elements(aux.URL); len(elems) > 0 {
+			json.Unmarshal(elems[0], &link)
+		}
+		a.MediaType = link.MediaType
+	}
+	return nil
+}
+
+func (t *Tag) UnmarshalJSON(data []byte) error {
+	type alias Tag
+	aux := &struct {
+		Href json.RawMessage `json:"href"`
+		*alias
+	}{alias: (*alias)(t)}
+	if err := json.Unmarshal(data, aux); err != nil {
+		return err
+	}
+	t.Href = reference(aux.Href, "href")
+	return nil
+}
+
+// reference returns the IRI a property holds: the string itself, the key
+// member of an embedded object ("id" for objects, "href" for links) or,
+// for an array, the first of those.
+func reference(raw json.RawMessage, key string) string {
+	for _, elem := range elements(raw) {
+		var s string
+		if err := json.Unmarshal(elem, &s); err == nil {
+			if s = strings.TrimSpace(s); s != "" {
+				return s
+			}
+			continue
+		}
+		var object map[string]json.RawMessage
+		if err := json.Unmarshal(elem, &object); err != nil {
+			continue
+		}
+		if s := reference(object[key], key); s != "" {
+			return s
+		}
+		if key != "id" {
+			if s := reference(object["id"], "id"); s != "" {
+				return s
+			}
+		}
+	}
+	return ""
+}
+
+// references returns the IRIs of every value of a property.
+func references(raw json.RawMessage, key string) (refs []string) {
+	for _, elem := range elements(raw) {
+		if s := reference(elem, key); s != "" {
+			refs = append(refs, s)
+		}
+	}
+	return
+}
+
+// elements returns the values of a property: the elements of an array, or
+// the value itself.
+func elements(raw json.RawMessage) []json.RawMessage {
+	if isEmptyJSON(raw) {
+		return nil
+	}
+	if !bytes.HasPrefix(bytes.TrimSpace(raw), []byte("[")) {
+		return []json.RawMessage{raw}
+	}
+	var elems []json.RawMessage
+	if err := json.Unmarshal(raw, &elems); err != nil {
+		return nil
+	}
+	return elems
+}
+
+// firstValue returns a value of a natural language map, picking the
+// first language in sorted order so that the choice is stable.
+func firstValue(m map[string]string) string {
+	langs := make([]string, 0, len(m))
+	for lang := range m {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+	for _, lang := range langs {
+		if m[lang] != "" {
+			return m[lang]
+		}
+	}
+	return ""
+}
+
+// coerceInt accepts a JSON number (integer or float) or a numeric string.
+func coerceInt(raw json.RawMessage) int {
+	if isEmptyJSON(raw) {
+		return 0
+	}
+	var n json.Number
+	if err := json.Unmarshal(raw, &n); err != nil {
+		var s string
+		if err := json.Unmarshal(raw, &s); err != nil {
+			return 0
+		}
+		n = json.Number(strings.TrimSpace(s))
+	}
+	f, err := n.Float64()
+	if err != nil || math.IsNaN(f) || f < math.MinInt32 || f > math.MaxInt32 {
+		return 0
+	}
+	return int(f)
+}
+
+func isEmptyJSON(raw json.RawMessage) bool {
+	trimmed := bytes.TrimSpace(raw)
+	return len(trimmed) == 0 || bytes.Equal(trimmed, []byte("null"))
+}
+
+// parseDateUTC parses a date, normalized to UTC, keeping nil for text
+// that is not a date.
+func parseDateUTC(text string) *time.Time {
+	return shared.ParseDateTime(text, false)
+}
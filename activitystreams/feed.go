@@ -0,0 +1,98 @@
+package activitystreams
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Collection is an ActivityStreams 2.0 Collection or OrderedCollection, or a
+// page of one, such as the outbox of an ActivityPub actor.
+// https://www.w3.org/TR/activitystreams-core/#collections
+type Collection struct {
+	ID         string `json:"id,omitempty"`
+	Type       string `json:"type,omitempty"`
+	Name       string `json:"name,omitempty"`
+	Summary    string `json:"summary,omitempty"`
+	TotalItems int    `json:"totalItems,omitempty"`
+	// First is the URL of the collection's first page. Servers that embed
+	// the first page instead of linking it leave it in FirstPage.
+	First     string      `json:"first,omitempty"`
+	FirstPage *Collection `json:"firstPage,omitempty"`
+	Last      string      `json:"last,omitempty"`
+	// Next, Prev and PartOf are only set on collection pages.
+	Next   string `json:"next,omitempty"`
+	Prev   string `json:"prev,omitempty"`
+	PartOf string `json:"partOf,omitempty"`
+	// Items holds the items or orderedItems of the collection, in order.
+	Items []*Activity `json:"orderedItems,omitempty"`
+}
+
+func (c Collection) String() string {
+	json, _ := json.MarshalIndent(c, "", "    ")
+	return string(json)
+}
+
+// IsPage reports whether the collection is a page of another collection.
+func (c *Collection) IsPage() bool {
+	return c.Type == "OrderedCollectionPage" || c.Type == "CollectionPage"
+}
+
+// Activity is an item of a collection: usually an activity such as Create
+// or Announce wrapping its Object. An item that is a bare object, such as a
+// Note, is read as an Activity with that Object; an item given only as an
+// IRI is read as an Activity with just that ID.
+type Activity struct {
+	ID              string     `json:"id,omitempty"`
+	Type            string     `json:"type,omitempty"`
+	Actor           string     `json:"actor,omitempty"`
+	Published       string     `json:"published,omitempty"`
+	PublishedParsed *time.Time `json:"publishedParsed,omitempty"`
+	To              []string   `json:"to,omitempty"`
+	CC              []string   `json:"cc,omitempty"`
+	// Object is nil for activities with no object. An Announce of a
+	// remote post usually carries only the object's IRI, in Object.ID.
+	Object *Object `json:"object,omitempty"`
+}
+
+// Object is an ActivityStreams object such as a Note or an Article.
+// https://www.w3.org/TR/activitystreams-vocabulary/#object-types
+type Object struct {
+	ID              string        `json:"id,omitempty"`
+	Type            string        `json:"type,omitempty"`
+	Name            string        `json:"name,omitempty"`
+	Summary         string        `json:"summary,omitempty"`
+	Content         string        `json:"content,omitempty"`
+	MediaType       string        `json:"mediaType,omitempty"`
+	URL             string        `json:"url,omitempty"`
+	AttributedTo    string        `json:"attributedTo,omitempty"`
+	InReplyTo       string        `json:"inReplyTo,omitempty"`
+	Published       string        `json:"published,omitempty"`
+	PublishedParsed *time.Time    `json:"publishedParsed,omitempty"`
+	Updated         string        `json:"updated,omitempty"`
+	UpdatedParsed   *time.Time    `json:"updatedParsed,omitempty"`
+	Sensitive       bool          `json:"sensitive,omitempty"`
+	To              []string      `json:"to,omitempty"`
+	CC              []string      `json:"cc,omitempty"`
+	Attachments     []*Attachment `json:"attachment,omitempty"`
+	Tags            []*Tag        `json:"tag,omitempty"`
+}
+
+// Attachment is a document attached to an object, such as an image or a
+// video.
+type Attachment struct {
+	Type      string `json:"type,omitempty"`
+	MediaType string `json:"mediaType,omitempty"`
+	URL       string `json:"url,omitempty"`
+	Name      string `json:"name,omitempty"`
+	Width     int    `json:"width,omitempty"`
+	Height    int    `json:"height,omitempty"`
+	// Duration is an xsd:duration such as "PT2M30S".
+	Duration string `json:"duration,omitempty"`
+}
+
+// Tag is a Hashtag, Mention or Emoji tag of an object.
+type Tag struct {
+	Type string `json:"type,omitempty"`
+	Name string `json:"name,omitempty"`
+	Href string `json:"href,omitempty"`
+}
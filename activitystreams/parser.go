@@ -0,0 +1,67 @@
+package activitystreams
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+
+	"github.com/mmcdole/gofeed/internal/shared"
+)
+
+// ErrNotCollection is returned by Parse for an ActivityStreams document
+// that is not a collection or a collection page.
+var ErrNotCollection = errors.New("activitystreams: document is not a collection")
+
+// collectionTypes are the types Parse accepts.
+var collectionTypes = map[string]bool{
+	"Collection":            true,
+	"CollectionPage":        true,
+	"OrderedCollection":     true,
+	"OrderedCollectionPage": true,
+}
+
+// Parser is an ActivityStreams 2.0 Parser
+type Parser struct {
+	// PreserveTimeZone keeps the offset dates are written with in the
+	// PublishedParsed and UpdatedParsed fields of activities and objects,
+	// which are otherwise normalized to UTC.
+	PreserveTimeZone bool
+}
+
+// Parse parses an ActivityStreams collection, such as an ActivityPub
+// outbox or one of its pages, into an activitystreams.Collection.
+func (ap *Parser) Parse(feed io.Reader) (*Collection, error) {
+	buffer := new(bytes.Buffer)
+	if _, err := buffer.ReadFrom(feed); err != nil {
+		return nil, err
+	}
+
+	collection := &Collection{}
+	if err := json.Unmarshal(buffer.Bytes(), collection); err != nil {
+		return nil, err
+	}
+	if !collectionTypes[collection.Type] {
+		return nil, ErrNotCollection
+	}
+	if ap.PreserveTimeZone {
+		preserveTimeZones(collection)
+	}
+	return collection, nil
+}
+
+// preserveTimeZones parses the dates of a collection's items again, keeping
+// their offsets: the Unmarshalers, which can take no options, normalize them
+// to UTC.
+func preserveTimeZones(c *Collection) {
+	for _, activity := range c.Items {
+		activity.PublishedParsed = shared.ParseDateTime(activity.Published, true)
+		if o := activity.Object; o != nil {
+			o.PublishedParsed = shared.ParseDateTime(o.Published, true)
+			o.UpdatedParsed = shared.ParseDateTime(o.Updated, true)
+		}
+	}
+	if c.FirstPage != nil {
+		preserveTimeZones(c.FirstPage)
+	}
+}
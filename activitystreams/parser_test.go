@@ -0,0 +1,69 @@
+package activitystreams_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mmcdole/gofeed/activitystreams"
+	"github.com/stretchr/testify/assert"
+)
+
+// Tests
+
+func TestParser_Parse(t *testing.T) {
+	files, _ := filepath.Glob("../testdata/parser/activitystreams/*.json")
+	for _, f := range files {
+		base := filepath.Base(f)
+		name := strings.TrimSuffix(base, filepath.Ext(base))
+
+		if strings.HasSuffix(name, "expected") {
+			continue
+		}
+
+		fmt.Printf("Testing %s... ", name)
+
+		// Get actual source document
+		ff := fmt.Sprintf("../testdata/parser/activitystreams/%s.json", name)
+		f, _ := os.ReadFile(ff)
+
+		// Parse actual document
+		fp := &activitystreams.Parser{}
+		actual, _ := fp.Parse(bytes.NewReader(f))
+
+		// Get json encoded expected result
+		ef := fmt.Sprintf("../testdata/parser/activitystreams/%s_expected.json", name)
+		e, _ := os.ReadFile(ef)
+
+		// Unmarshal expected result
+		expected := &activitystreams.Collection{}
+		json.Unmarshal(e, &expected)
+
+		if assert.Equal(t, expected, actual, "Document file %s.json did not match expected output %s_expected.json", name, name) {
+			fmt.Printf("OK\n")
+		} else {
+			fmt.Printf("Failed\n")
+		}
+	}
+}
+
+func TestParser_Parse_NotCollection(t *testing.T) {
+	fp := &activitystreams.Parser{}
+	_, err := fp.Parse(strings.NewReader(`{
+		"@context": "https://www.w3.org/ns/activitystreams",
+		"id": "https://social.example/users/jane",
+		"type": "Person",
+		"outbox": "https://social.example/users/jane/outbox"
+	}`))
+	assert.Equal(t, activitystreams.ErrNotCollection, err)
+}
+
+func TestParser_Parse_Invalid(t *testing.T) {
+	fp := &activitystreams.Parser{}
+	_, err := fp.Parse(strings.NewReader(`{"type": "OrderedCollection", "orderedItems": [`))
+	assert.Error(t, err)
+}
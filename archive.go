@@ -0,0 +1,151 @@
+package gofeed
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	ext "github.com/mmcdole/gofeed/extensions"
+)
+
+// FeedHistory describes where a feed document sits in its feed's history,
+// following Feed Paging and Archiving (RFC 5005). It is read from the fh:
+// elements and the current, prev-archive and next-archive links of Atom
+// feeds and of atom:link elements embedded in RSS.
+type FeedHistory struct {
+	// Complete reports that the document holds every entry of the feed
+	// (fh:complete), so there are no archives to fetch.
+	Complete bool `json:"complete,omitempty"`
+	// Archive reports that the document is an archive whose entries will
+	// not change (fh:archive).
+	Archive bool `json:"archive,omitempty"`
+	// Current is the subscription document of an archive.
+	Current string `json:"current,omitempty"`
+	// PrevArchive is the archive holding the entries just before this
+	// document's.
+	PrevArchive string `json:"prevArchive,omitempty"`
+	// NextArchive is the archive holding the entries just after this
+	// document's.
+	NextArchive string `json:"nextArchive,omitempty"`
+	// Exhaustive is only set by ParseURLArchiveWithContext, on the Feed it
+	// returns: the history it reconstructed is known to hold every entry.
+	Exhaustive bool `json:"exhaustive,omitempty"`
+}
+
+// newFeedHistory builds a FeedHistory from a document's fh: extension and
+// its links, looked up by rel with linkHref. It returns nil when the
+// document carries none of them.
+func newFeedHistory(exts ext.Extensions, linkHref func(rel string) string) *FeedHistory {
+	history := &FeedHistory{
+		Current:     linkHref("current"),
+		PrevArchive: linkHref("prev-archive"),
+		NextArchive: linkHref("next-archive"),
+	}
+	if fh, ok := ext.Get[*ext.FeedHistoryExtension](exts); ok {
+		history.Complete = fh.Complete
+		history.Archive = fh.Archive
+	}
+	if *history == (FeedHistory{}) {
+		return nil
+	}
+	return history
+}
+
+// ParseURLArchiveWithContext reconstructs the full history of the feed whose
+// subscription document is feedURL. Unless the subscription document is
+// marked fh:complete, it follows the prev-archive links back through the
+// feed's archives (RFC 5005, section 4), fetching each with the same HTTP
+// settings as ParseURLWithContext. opts limits the walk the way it limits
+// ParseURLPagesWithContext, counting archive documents as pages.
+//
+// The returned Feed carries the subscription document's metadata and the
+// items of every document fetched. An entry that appears in more than one
+// document (same GUID, or same link when it has no GUID) is kept in the
+// version from the newest document, as RFC 5005 requires. Items are
+// ordered oldest first by publish date, or by update date when they have
+// none; when some item has neither, the archive order is kept instead:
+// the oldest archive first, each document's items reversed since feeds
+// list their newest entries first.
+//
+// The Feed's History.Exhaustive reports whether the history is known to be
+// complete: the subscription document is marked fh:complete, or the walk
+// reached an archive with no prev-archive link, and no limit cut it short.
+// History.Complete is left as the subscription document declares it. When a
+// limit stopped the walk, History.PrevArchive is the archive that was not
+// fetched, or the one MaxItems cut short, so a later call can resume from
+// it; otherwise it is "".
+func (f *Parser) ParseURLArchiveWithContext(feedURL string, ctx context.Context, opts PageOptions) (*Feed, error) {
+	var current, last *Feed
+	var pages [][]*Item
+	archives := 0
+
+	// fh:complete means the subscription document is the whole history, so
+	// its prev-archive link is not followed.
+	link := func(page *Feed) string {
+		if page == current && page.History != nil && page.History.Complete {
+			return ""
+		}
+		return prevArchiveURL(page)
+	}
+
+	// The walk drops entries already seen in a newer document, and counts
+	// each once towards MaxItems.
+	prev, err := f.walkPages(feedURL, ctx, opts, link, true, func(page *Feed) error {
+		if current == nil {
+			current = page
+		} else {
+			archives++
+		}
+		last = page
+		pages = append(pages, page.Items)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if current.History == nil {
+		current.History = &FeedHistory{}
+	}
+	// A walk that ended on its own stopped either at the first archive, or
+	// at an archive link that led back to a document already fetched.
+	current.History.Exhaustive = prev == "" &&
+		(current.History.Complete || archives > 0 && prevArchiveURL(last) == "")
+	current.History.PrevArchive = prev
+
+	current.Items = []*Item{}
+	for i := len(pages) - 1; i >= 0; i-- {
+		for j := len(pages[i]) - 1; j >= 0; j-- {
+			current.Items = append(current.Items, pages[i][j])
+		}
+	}
+	sortChronologically(current.Items)
+	return current, nil
+}
+
+// prevArchiveURL returns the link the archive fetcher follows.
+func prevArchiveURL(page *Feed) string {
+	if page.History == nil {
+		return ""
+	}
+	return page.History.PrevArchive
+}
+
+// sortChronologically orders items oldest first when every item is dated,
+// and leaves them in place otherwise.
+func sortChronologically(items []*Item) {
+	dates := make(map[*Item]time.Time, len(items))
+	for _, item := range items {
+		date := item.PublishedParsed
+		if date == nil {
+			date = item.UpdatedParsed
+		}
+		if date == nil {
+			return
+		}
+		dates[item] = *date
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		return dates[items[i]].Before(dates[items[j]])
+	})
+}
@@ -0,0 +1,125 @@
+package gofeed_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mmcdole/gofeed"
+	"github.com/stretchr/testify/assert"
+)
+
+// archivedServer serves an Atom feed archived in two documents, an RSS
+// subscription document marked fh:complete, and an archive whose
+// prev-archive link leads back to its subscription document.
+func archivedServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	atomDoc := func(path, title, links string, entries ...string) {
+		mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintf(w, `<feed xmlns="http://www.w3.org/2005/Atom" xmlns:fh="http://purl.org/syndication/history/1.0"><title>%s</title>%s`, title, links)
+			for _, entry := range entries {
+				fmt.Fprint(w, entry)
+			}
+			fmt.Fprint(w, `</feed>`)
+		})
+	}
+	entry := func(id, title, updated string) string {
+		return fmt.Sprintf(`<entry><id>%s</id><title>%s</title><updated>%s</updated></entry>`, id, title, updated)
+	}
+
+	atomDoc("/feed", "current", `<link rel="prev-archive" href="/archive/2"/>`,
+		entry("e4", "four", "2024-04-01T00:00:00Z"),
+		entry("e3", "three, edited", "2024-03-15T00:00:00Z"))
+	atomDoc("/archive/2", "archive 2", `<fh:archive/><link rel="prev-archive" href="1"/>`,
+		entry("e3", "three", "2024-03-01T00:00:00Z"),
+		entry("e2", "two", "2024-02-01T00:00:00Z"))
+	atomDoc("/archive/1", "archive 1", `<fh:archive/>`,
+		entry("e1", "one", "2024-01-01T00:00:00Z"))
+
+	atomDoc("/looped", "looped", `<link rel="prev-archive" href="/looped/archive"/>`,
+		entry("l2", "two", "2024-02-01T00:00:00Z"))
+	atomDoc("/looped/archive", "looped archive", `<fh:archive/><link rel="prev-archive" href="/looped"/>`,
+		entry("l1", "one", "2024-01-01T00:00:00Z"))
+
+	mux.HandleFunc("/complete.rss", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `<rss version="2.0" xmlns:fh="http://purl.org/syndication/history/1.0"
+			xmlns:atom="http://www.w3.org/2005/Atom"><channel><title>complete</title><fh:complete/>
+			<atom:link rel="prev-archive" href="/archive/1"/>
+			<item><guid>b</guid></item><item><guid>a</guid></item></channel></rss>`)
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func itemTitles(feed *gofeed.Feed) (titles []string) {
+	for _, item := range feed.Items {
+		titles = append(titles, item.Title)
+	}
+	return
+}
+
+func TestParser_ParseURLArchive(t *testing.T) {
+	server := archivedServer(t)
+	fp := gofeed.NewParser()
+	ctx := context.Background()
+
+	// Every archive, oldest first, with the entry repeated in an archive
+	// kept in its newer version.
+	feed, err := fp.ParseURLArchiveWithContext(server.URL+"/feed", ctx, gofeed.PageOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, "current", feed.Title)
+	assert.Equal(t, []string{"one", "two", "three, edited", "four"}, itemTitles(feed))
+	assert.True(t, feed.History.Exhaustive)
+	assert.False(t, feed.History.Complete)
+	assert.Equal(t, "", feed.History.PrevArchive)
+
+	// A page limit leaves the history incomplete, with the unfetched
+	// archive to resume from.
+	feed, err = fp.ParseURLArchiveWithContext(server.URL+"/feed", ctx, gofeed.PageOptions{MaxPages: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"two", "three, edited", "four"}, itemTitles(feed))
+	assert.False(t, feed.History.Exhaustive)
+	assert.Equal(t, server.URL+"/archive/1", feed.History.PrevArchive)
+
+	// An item limit counts the entry repeated in an archive once, and
+	// leaves the archive it cut short to resume from.
+	feed, err = fp.ParseURLArchiveWithContext(server.URL+"/feed", ctx, gofeed.PageOptions{MaxItems: 3})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"two", "three, edited", "four"}, itemTitles(feed))
+	assert.False(t, feed.History.Exhaustive)
+	assert.Equal(t, server.URL+"/archive/1", feed.History.PrevArchive)
+
+	feed, err = fp.ParseURLArchiveWithContext(server.URL+"/feed", ctx, gofeed.PageOptions{MaxItems: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"four"}, itemTitles(feed))
+	assert.False(t, feed.History.Exhaustive)
+	assert.Equal(t, server.URL+"/feed", feed.History.PrevArchive)
+
+	// fh:complete means the subscription document is the whole history;
+	// its prev-archive link is not followed. Undated items keep their
+	// document order, reversed.
+	feed, err = fp.ParseURLArchiveWithContext(server.URL+"/complete.rss", ctx, gofeed.PageOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, itemIDs(feed))
+	assert.True(t, feed.History.Complete)
+	assert.True(t, feed.History.Exhaustive)
+	assert.Equal(t, "", feed.History.PrevArchive)
+
+	// An archive chain that loops back is not known to be complete.
+	feed, err = fp.ParseURLArchiveWithContext(server.URL+"/looped", ctx, gofeed.PageOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"one", "two"}, itemTitles(feed))
+	assert.False(t, feed.History.Exhaustive)
+
+	// A document with no archives behind it is not known to be complete
+	// either.
+	feed, err = fp.ParseURLArchiveWithContext(server.URL+"/archive/1", ctx, gofeed.PageOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"one"}, itemTitles(feed))
+	assert.False(t, feed.History.Exhaustive)
+	assert.True(t, feed.History.Archive)
+}
@@ -9,23 +9,26 @@ import (
 
 // Feed is an Atom Feed
 type Feed struct {
-	Title         string         `json:"title,omitempty"`
-	ID            string         `json:"id,omitempty"`
-	Updated       string         `json:"updated,omitempty"`
-	UpdatedParsed *time.Time     `json:"updatedParsed,omitempty"`
-	Subtitle      string         `json:"subtitle,omitempty"`
-	Links         []*Link        `json:"links,omitempty"`
-	Language      string         `json:"language,omitempty"`
-	Generator     *Generator     `json:"generator,omitempty"`
-	Icon          string         `json:"icon,omitempty"`
-	Logo          string         `json:"logo,omitempty"`
-	Rights        string         `json:"rights,omitempty"`
-	Contributors  []*Person      `json:"contributors,omitempty"`
-	Authors       []*Person      `json:"authors,omitempty"`
-	Categories    []*Category    `json:"categories,omitempty"`
-	Entries       []*Entry       `json:"entries"`
-	Extensions    ext.Extensions `json:"extensions,omitempty"`
-	Version       string         `json:"version"`
+	Title         string      `json:"title,omitempty"`
+	ID            string      `json:"id,omitempty"`
+	Updated       string      `json:"updated,omitempty"`
+	UpdatedParsed *time.Time  `json:"updatedParsed,omitempty"`
+	Subtitle      string      `json:"subtitle,omitempty"`
+	Links         []*Link     `json:"links,omitempty"`
+	Language      string      `json:"language,omitempty"`
+	Generator     *Generator  `json:"generator,omitempty"`
+	Icon          string      `json:"icon,omitempty"`
+	Logo          string      `json:"logo,omitempty"`
+	Rights        string      `json:"rights,omitempty"`
+	Contributors  []*Person   `json:"contributors,omitempty"`
+	Authors       []*Person   `json:"authors,omitempty"`
+	Categories    []*Category `json:"categories,omitempty"`
+	Entries       []*Entry    `json:"entries"`
+	// DeletedEntries are the RFC 6721 tombstones (at:deleted-entry) of
+	// entries the publisher has removed.
+	DeletedEntries []*DeletedEntry `json:"deletedEntries,omitempty"`
+	Extensions     ext.Extensions  `json:"extensions,omitempty"`
+	Version        string          `json:"version"`
 }
 
 func (f Feed) String() string {
@@ -35,21 +38,37 @@ func (f Feed) String() string {
 
 // Entry is an Atom Entry
 type Entry struct {
-	Title           string         `json:"title,omitempty"`
-	ID              string         `json:"id,omitempty"`
-	Updated         string         `json:"updated,omitempty"`
-	UpdatedParsed   *time.Time     `json:"updatedParsed,omitempty"`
-	Summary         string         `json:"summary,omitempty"`
-	Authors         []*Person      `json:"authors,omitempty"`
-	Contributors    []*Person      `json:"contributors,omitempty"`
-	Categories      []*Category    `json:"categories,omitempty"`
-	Links           []*Link        `json:"links,omitempty"`
-	Rights          string         `json:"rights,omitempty"`
-	Published       string         `json:"published,omitempty"`
-	PublishedParsed *time.Time     `json:"publishedParsed,omitempty"`
-	Source          *Source        `json:"source,omitempty"`
-	Content         *Content       `json:"content,omitempty"`
-	Extensions      ext.Extensions `json:"extensions,omitempty"`
+	Title           string      `json:"title,omitempty"`
+	ID              string      `json:"id,omitempty"`
+	Updated         string      `json:"updated,omitempty"`
+	UpdatedParsed   *time.Time  `json:"updatedParsed,omitempty"`
+	Summary         string      `json:"summary,omitempty"`
+	Authors         []*Person   `json:"authors,omitempty"`
+	Contributors    []*Person   `json:"contributors,omitempty"`
+	Categories      []*Category `json:"categories,omitempty"`
+	Links           []*Link     `json:"links,omitempty"`
+	Rights          string      `json:"rights,omitempty"`
+	Published       string      `json:"published,omitempty"`
+	PublishedParsed *time.Time  `json:"publishedParsed,omitempty"`
+	Source          *Source     `json:"source,omitempty"`
+	Content         *Content    `json:"content,omitempty"`
+	// Edited and Draft are the AtomPub (RFC 5023) app:edited date and
+	// app:control/app:draft flag of entries fetched from a collection.
+	Edited       string         `json:"edited,omitempty"`
+	EditedParsed *time.Time     `json:"editedParsed,omitempty"`
+	Draft        bool           `json:"draft,omitempty"`
+	Extensions   ext.Extensions `json:"extensions,omitempty"`
+}
+
+// DeletedEntry is a tombstone (RFC 6721 at:deleted-entry) announcing that
+// the entry whose ID is Ref has been removed from the feed.
+type DeletedEntry struct {
+	Ref        string     `json:"ref,omitempty"`
+	When       string     `json:"when,omitempty"`
+	WhenParsed *time.Time `json:"whenParsed,omitempty"`
+	By         *Person    `json:"by,omitempty"`
+	Comment    string     `json:"comment,omitempty"`
+	Links      []*Link    `json:"links,omitempty"`
 }
 
 // Category is category metadata for Feeds and Entries
@@ -76,6 +95,10 @@ type Link struct {
 	Type     string `json:"type,omitempty"`
 	Title    string `json:"title,omitempty"`
 	Length   string `json:"length,omitempty"`
+	// ThreadCount and ThreadUpdated are the RFC 4685 thr:count and
+	// thr:updated attributes of a rel="replies" link.
+	ThreadCount   string `json:"thrCount,omitempty"`
+	ThreadUpdated string `json:"thrUpdated,omitempty"`
 }
 
 // Content either contains or links to the content of
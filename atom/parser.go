@@ -25,8 +25,23 @@ var (
 	}
 )
 
+const (
+	// tombstonesNS is the namespace of the RFC 6721 deleted-entry element.
+	tombstonesNS = "http://purl.org/atompub/tombstones/1.0"
+)
+
 // Parser is an Atom Parser
-type Parser struct{}
+type Parser struct {
+	// PreserveExtensionXML keeps the raw inner XML of every extension
+	// element and its text, comments and children in document order
+	// (ext.Extension InnerXML and Content). Off by default, as it roughly
+	// doubles the memory held by extensions.
+	PreserveExtensionXML bool
+	// PreserveTimeZone keeps the offset dates are written with in the
+	// Parsed fields (UpdatedParsed, PublishedParsed, EditedParsed and
+	// WhenParsed), which are otherwise normalized to UTC.
+	PreserveTimeZone bool
+}
 
 // Parse parses an xml feed into an atom.Feed
 func (ap *Parser) Parse(feed io.Reader) (*Feed, error) {
@@ -58,9 +73,18 @@ func (ap *Parser) parseRoot(p *xpp.Parser) (*Feed, error) {
 	extensions := ext.Extensions{}
 
 	err := shared.ForEachChild(p, func(name string) error {
+		// The namespace is trimmed as in shared.PrefixForNamespace, since
+		// the attribute declaring it may carry surrounding whitespace.
+		if strings.TrimSpace(p.Space()) == tombstonesNS && name == "deleted-entry" {
+			deleted, err := ap.parseDeletedEntry(p)
+			if err == nil {
+				atom.DeletedEntries = append(atom.DeletedEntries, deleted)
+			}
+			return err
+		}
 		if shared.IsExtension(p) {
 			var err error
-			extensions, err = shared.ParseExtension(extensions, p)
+			extensions, err = shared.ParseExtension(extensions, p, ap.PreserveExtensionXML)
 			return err
 		}
 		var err error
@@ -71,7 +95,7 @@ func (ap *Parser) parseRoot(p *xpp.Parser) (*Feed, error) {
 			atom.ID, err = ap.parseAtomText(p)
 		case "updated", "modified":
 			if atom.Updated, err = ap.parseAtomText(p); err == nil {
-				atom.UpdatedParsed = parseDateUTC(atom.Updated)
+				atom.UpdatedParsed = ap.parseDate(atom.Updated)
 			}
 		case "subtitle", "tagline":
 			atom.Subtitle, err = ap.parseAtomText(p)
@@ -144,14 +168,11 @@ func (ap *Parser) parseRoot(p *xpp.Parser) (*Feed, error) {
 	return atom, nil
 }
 
-// parseDateUTC parses a date the historical way: the raw text is kept by the
-// caller even when unparseable, and the parsed form is normalized to UTC.
-func parseDateUTC(text string) *time.Time {
-	if date, err := shared.ParseDate(text); err == nil {
-		utc := date.UTC()
-		return &utc
-	}
-	return nil
+// parseDate parses a date the historical way: the raw text is kept by the
+// caller even when unparseable, and the parsed form is normalized to UTC
+// unless PreserveTimeZone is set.
+func (ap *Parser) parseDate(text string) *time.Time {
+	return shared.ParseDateTime(text, ap.PreserveTimeZone)
 }
 
 func (ap *Parser) parseEntry(p *xpp.Parser) (*Entry, error) {
@@ -167,9 +188,20 @@ func (ap *Parser) parseEntry(p *xpp.Parser) (*Entry, error) {
 	extensions := ext.Extensions{}
 
 	err := shared.ForEachChild(p, func(name string) error {
+		if shared.IsAppNamespace(p.Space()) && (name == "edited" || name == "control") {
+			var err error
+			if name == "edited" {
+				if entry.Edited, err = ap.parseAtomText(p); err == nil {
+					entry.EditedParsed = ap.parseDate(entry.Edited)
+				}
+			} else {
+				entry.Draft, err = ap.parseControl(p)
+			}
+			return err
+		}
 		if shared.IsExtension(p) {
 			var err error
-			extensions, err = shared.ParseExtension(extensions, p)
+			extensions, err = shared.ParseExtension(extensions, p, ap.PreserveExtensionXML)
 			return err
 		}
 		var err error
@@ -186,7 +218,7 @@ func (ap *Parser) parseEntry(p *xpp.Parser) (*Entry, error) {
 			entry.Source, err = ap.parseSource(p)
 		case "updated", "modified":
 			if entry.Updated, err = ap.parseAtomText(p); err == nil {
-				entry.UpdatedParsed = parseDateUTC(entry.Updated)
+				entry.UpdatedParsed = ap.parseDate(entry.Updated)
 			}
 		case "contributor":
 			var person *Person
@@ -210,7 +242,7 @@ func (ap *Parser) parseEntry(p *xpp.Parser) (*Entry, error) {
 			}
 		case "published", "issued":
 			if entry.Published, err = ap.parseAtomText(p); err == nil {
-				entry.PublishedParsed = parseDateUTC(entry.Published)
+				entry.PublishedParsed = ap.parseDate(entry.Published)
 			}
 		case "content":
 			entry.Content, err = ap.parseContent(p)
@@ -250,6 +282,90 @@ func (ap *Parser) parseEntry(p *xpp.Parser) (*Entry, error) {
 	return entry, nil
 }
 
+// parseControl reports whether an app:control element marks its entry as
+// a draft.
+func (ap *Parser) parseControl(p *xpp.Parser) (bool, error) {
+	if err := p.Expect(xpp.StartTag, "control"); err != nil {
+		return false, err
+	}
+
+	draft := false
+	err := shared.ForEachChild(p, func(name string) error {
+		if name != "draft" {
+			return p.Skip()
+		}
+		value, err := ap.parseAtomText(p)
+		draft = strings.EqualFold(value, "yes")
+		return err
+	})
+	if err != nil {
+		return false, err
+	}
+
+	if err := p.Expect(xpp.EndTag, "control"); err != nil {
+		return false, err
+	}
+
+	return draft, nil
+}
+
+func (ap *Parser) parseDeletedEntry(p *xpp.Parser) (*DeletedEntry, error) {
+	if err := p.Expect(xpp.StartTag, "deleted-entry"); err != nil {
+		return nil, err
+	}
+
+	deleted := &DeletedEntry{}
+	deleted.Ref = p.Attribute("ref")
+	deleted.When = p.Attribute("when")
+	deleted.WhenParsed = ap.parseDate(deleted.When)
+
+	links := []*Link{}
+
+	err := shared.ForEachChild(p, func(name string) error {
+		var err error
+		switch name {
+		case "by":
+			deleted.By, err = ap.parsePerson("by", p)
+		case "comment":
+			deleted.Comment, err = ap.parseAtomText(p)
+		case "link":
+			var link *Link
+			if link, err = ap.parseLink(p); err == nil {
+				links = append(links, link)
+			}
+		case "ref", "when":
+			// Some publishers write ref and when as child elements
+			// rather than the attributes RFC 6721 defines; the
+			// attributes win when both are given.
+			var value string
+			if value, err = ap.parseAtomText(p); err == nil {
+				if name == "ref" && deleted.Ref == "" {
+					deleted.Ref = value
+				} else if name == "when" && deleted.When == "" {
+					deleted.When = value
+					deleted.WhenParsed = ap.parseDate(value)
+				}
+			}
+		default:
+			err = p.Skip()
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(links) > 0 {
+		deleted.Links = links
+	}
+
+	if err := p.Expect(xpp.EndTag, "deleted-entry"); err != nil {
+		return nil, err
+	}
+
+	return deleted, nil
+}
+
 func (ap *Parser) parseSource(p *xpp.Parser) (*Source, error) {
 	if err := p.Expect(xpp.StartTag, "source"); err != nil {
 		return nil, err
@@ -266,7 +382,7 @@ func (ap *Parser) parseSource(p *xpp.Parser) (*Source, error) {
 	err := shared.ForEachChild(p, func(name string) error {
 		if shared.IsExtension(p) {
 			var err error
-			extensions, err = shared.ParseExtension(extensions, p)
+			extensions, err = shared.ParseExtension(extensions, p, ap.PreserveExtensionXML)
 			return err
 		}
 		var err error
@@ -277,7 +393,7 @@ func (ap *Parser) parseSource(p *xpp.Parser) (*Source, error) {
 			source.ID, err = ap.parseAtomText(p)
 		case "updated", "modified":
 			if source.Updated, err = ap.parseAtomText(p); err == nil {
-				source.UpdatedParsed = parseDateUTC(source.Updated)
+				source.UpdatedParsed = ap.parseDate(source.Updated)
 			}
 		case "subtitle", "tagline":
 			source.Subtitle, err = ap.parseAtomText(p)
@@ -406,6 +522,10 @@ func (ap *Parser) parseLink(p *xpp.Parser) (*Link, error) {
 	if l.Rel == "" {
 		l.Rel = "alternate"
 	}
+	if l.Rel == "replies" {
+		l.ThreadCount = p.Attribute("count")
+		l.ThreadUpdated = p.Attribute("updated")
+	}
 
 	if err := p.Skip(); err != nil {
 		return nil, err
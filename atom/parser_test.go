@@ -8,6 +8,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/mmcdole/gofeed/atom"
 	"github.com/stretchr/testify/assert"
@@ -48,3 +49,22 @@ func TestParser_Parse(t *testing.T) {
 }
 
 // TODO: Examples
+
+func TestParser_Parse_PreserveTimeZone(t *testing.T) {
+	feed := `<feed xmlns="http://www.w3.org/2005/Atom"><updated>2024-10-05T14:30:00+02:00</updated>` +
+		`<entry><published>2024-10-05T09:00:00-05:00</published></entry></feed>`
+
+	actual, err := (&atom.Parser{}).Parse(strings.NewReader(feed))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "2024-10-05T12:30:00Z", actual.UpdatedParsed.Format(time.RFC3339))
+	assert.Equal(t, "2024-10-05T14:00:00Z", actual.Entries[0].PublishedParsed.Format(time.RFC3339))
+
+	actual, err = (&atom.Parser{PreserveTimeZone: true}).Parse(strings.NewReader(feed))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "2024-10-05T14:30:00+02:00", actual.UpdatedParsed.Format(time.RFC3339))
+	assert.Equal(t, "2024-10-05T09:00:00-05:00", actual.Entries[0].PublishedParsed.Format(time.RFC3339))
+}
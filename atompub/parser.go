@@ -0,0 +1,243 @@
+package atompub
+
+import (
+	"io"
+	"strings"
+
+	"github.com/mmcdole/gofeed/atom"
+	ext "github.com/mmcdole/gofeed/extensions"
+	"github.com/mmcdole/gofeed/internal/shared"
+	xpp "github.com/mmcdole/goxpp/v2"
+)
+
+const atomNS = "http://www.w3.org/2005/Atom"
+
+// Parser is an AtomPub service and category document parser
+type Parser struct{}
+
+// ParseService parses an AtomPub service document (app:service).
+func (sp *Parser) ParseService(doc io.Reader) (*Service, error) {
+	p, err := sp.root(doc)
+	if err != nil {
+		return nil, err
+	}
+	return sp.parseService(p)
+}
+
+// ParseCategories parses a standalone AtomPub category document
+// (app:categories), such as the one an out-of-line Categories.Href
+// points to.
+func (sp *Parser) ParseCategories(doc io.Reader) (*Categories, error) {
+	p, err := sp.root(doc)
+	if err != nil {
+		return nil, err
+	}
+	return sp.parseCategories(p)
+}
+
+func (sp *Parser) root(doc io.Reader) (*xpp.Parser, error) {
+	doc = shared.NewControlCharFilterReader(doc)
+	p := shared.NewXMLParser(doc)
+
+	if _, err := shared.FindRoot(p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// isCore reports whether the current element is an AtomPub or Atom
+// element rather than an extension. Elements without a namespace are
+// taken as core elements, as the feed parsers do.
+func isCore(p *xpp.Parser) bool {
+	switch space := strings.TrimSpace(p.Space()); space {
+	case "", atomNS:
+		return true
+	default:
+		return shared.IsAppNamespace(space)
+	}
+}
+
+func (sp *Parser) parseService(p *xpp.Parser) (*Service, error) {
+	if err := p.Expect(xpp.StartTag, "service"); err != nil {
+		return nil, err
+	}
+
+	service := &Service{}
+	service.Workspaces = []*Workspace{}
+	extensions := ext.Extensions{}
+
+	err := shared.ForEachChild(p, func(name string) error {
+		if !isCore(p) {
+			var err error
+			extensions, err = shared.ParseExtension(extensions, p, false)
+			return err
+		}
+		if name != "workspace" {
+			return p.Skip()
+		}
+		workspace, err := sp.parseWorkspace(p)
+		if err == nil {
+			service.Workspaces = append(service.Workspaces, workspace)
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(extensions) > 0 {
+		service.Extensions = extensions
+	}
+
+	if err := p.Expect(xpp.EndTag, "service"); err != nil {
+		return nil, err
+	}
+
+	return service, nil
+}
+
+func (sp *Parser) parseWorkspace(p *xpp.Parser) (*Workspace, error) {
+	if err := p.Expect(xpp.StartTag, "workspace"); err != nil {
+		return nil, err
+	}
+
+	workspace := &Workspace{}
+	extensions := ext.Extensions{}
+
+	err := shared.ForEachChild(p, func(name string) error {
+		if !isCore(p) {
+			var err error
+			extensions, err = shared.ParseExtension(extensions, p, false)
+			return err
+		}
+		var err error
+		switch name {
+		case "title":
+			workspace.Title, err = shared.ParseText(p)
+		case "collection":
+			var collection *Collection
+			if collection, err = sp.parseCollection(p); err == nil {
+				workspace.Collections = append(workspace.Collections, collection)
+			}
+		default:
+			err = p.Skip()
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(extensions) > 0 {
+		workspace.Extensions = extensions
+	}
+
+	if err := p.Expect(xpp.EndTag, "workspace"); err != nil {
+		return nil, err
+	}
+
+	return workspace, nil
+}
+
+func (sp *Parser) parseCollection(p *xpp.Parser) (*Collection, error) {
+	if err := p.Expect(xpp.StartTag, "collection"); err != nil {
+		return nil, err
+	}
+
+	collection := &Collection{}
+	collection.Href = p.Attribute("href")
+	extensions := ext.Extensions{}
+
+	err := shared.ForEachChild(p, func(name string) error {
+		if !isCore(p) {
+			var err error
+			extensions, err = shared.ParseExtension(extensions, p, false)
+			return err
+		}
+		var err error
+		switch name {
+		case "title":
+			collection.Title, err = shared.ParseText(p)
+		case "accept":
+			var accept string
+			if accept, err = shared.ParseText(p); err == nil {
+				collection.Accept = append(collection.Accept, accept)
+			}
+		case "categories":
+			var categories *Categories
+			if categories, err = sp.parseCategories(p); err == nil {
+				collection.Categories = append(collection.Categories, categories)
+			}
+		default:
+			err = p.Skip()
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(extensions) > 0 {
+		collection.Extensions = extensions
+	}
+
+	if err := p.Expect(xpp.EndTag, "collection"); err != nil {
+		return nil, err
+	}
+
+	return collection, nil
+}
+
+func (sp *Parser) parseCategories(p *xpp.Parser) (*Categories, error) {
+	if err := p.Expect(xpp.StartTag, "categories"); err != nil {
+		return nil, err
+	}
+
+	categories := &Categories{}
+	categories.Href = p.Attribute("href")
+	categories.Fixed = strings.EqualFold(p.Attribute("fixed"), "yes")
+	categories.Scheme = p.Attribute("scheme")
+
+	err := shared.ForEachChild(p, func(name string) error {
+		if !isCore(p) || name != "category" {
+			return p.Skip()
+		}
+		category, err := sp.parseCategory(p)
+		if err == nil {
+			if category.Scheme == "" {
+				category.Scheme = categories.Scheme
+			}
+			categories.Categories = append(categories.Categories, category)
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.Expect(xpp.EndTag, "categories"); err != nil {
+		return nil, err
+	}
+
+	return categories, nil
+}
+
+func (sp *Parser) parseCategory(p *xpp.Parser) (*atom.Category, error) {
+	if err := p.Expect(xpp.StartTag, "category"); err != nil {
+		return nil, err
+	}
+
+	c := &atom.Category{}
+	c.Term = p.Attribute("term")
+	c.Scheme = p.Attribute("scheme")
+	c.Label = p.Attribute("label")
+
+	if err := p.Skip(); err != nil {
+		return nil, err
+	}
+
+	if err := p.Expect(xpp.EndTag, "category"); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
@@ -0,0 +1,76 @@
+package atompub_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mmcdole/gofeed/atompub"
+	"github.com/stretchr/testify/assert"
+)
+
+// Tests
+
+// TestParser_Parse runs the fixtures, parsing service_*.xml files as
+// service documents and categories_*.xml files as category documents.
+func TestParser_Parse(t *testing.T) {
+	files, _ := filepath.Glob("../testdata/parser/atompub/*.xml")
+	for _, f := range files {
+		base := filepath.Base(f)
+		name := strings.TrimSuffix(base, filepath.Ext(base))
+
+		fmt.Printf("Testing %s... ", name)
+
+		// Get actual source document
+		ff := fmt.Sprintf("../testdata/parser/atompub/%s.xml", name)
+		f, _ := os.ReadFile(ff)
+
+		// Get json encoded expected result
+		ef := fmt.Sprintf("../testdata/parser/atompub/%s.json", name)
+		e, _ := os.ReadFile(ef)
+
+		// Parse actual document and unmarshal the expected one
+		sp := &atompub.Parser{}
+		var actual, expected interface{}
+		if strings.HasPrefix(name, "categories_") {
+			actual, _ = sp.ParseCategories(bytes.NewReader(f))
+			expected = &atompub.Categories{}
+		} else {
+			actual, _ = sp.ParseService(bytes.NewReader(f))
+			expected = &atompub.Service{}
+		}
+		json.Unmarshal(e, expected)
+
+		if assert.Equal(t, expected, actual, "Document file %s.xml did not match expected output %s.json", name, name) {
+			fmt.Printf("OK\n")
+		} else {
+			fmt.Printf("Failed\n")
+		}
+	}
+}
+
+func TestParser_ParseService_WrongRoot(t *testing.T) {
+	sp := &atompub.Parser{}
+	_, err := sp.ParseService(strings.NewReader(`<feed xmlns="http://www.w3.org/2005/Atom"/>`))
+	assert.Error(t, err)
+}
+
+func TestCollection_AcceptedMediaTypes(t *testing.T) {
+	tests := []struct {
+		accept   []string
+		expected []string
+	}{
+		{nil, []string{"application/atom+xml;type=entry"}},
+		{[]string{""}, nil},
+		{[]string{"image/png", "image/*"}, []string{"image/png", "image/*"}},
+	}
+
+	for _, test := range tests {
+		c := &atompub.Collection{Accept: test.accept}
+		assert.Equal(t, test.expected, c.AcceptedMediaTypes(), "accept %q", test.accept)
+	}
+}
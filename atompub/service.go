@@ -0,0 +1,85 @@
+package atompub
+
+import (
+	"encoding/json"
+
+	"github.com/mmcdole/gofeed/atom"
+	ext "github.com/mmcdole/gofeed/extensions"
+)
+
+// entryMediaType is the media type a collection accepts when it has no
+// app:accept elements.
+const entryMediaType = "application/atom+xml;type=entry"
+
+// Service is an AtomPub (RFC 5023) service document, describing the
+// workspaces and collections of a server.
+type Service struct {
+	Workspaces []*Workspace   `json:"workspaces"`
+	Extensions ext.Extensions `json:"extensions,omitempty"`
+}
+
+func (s Service) String() string {
+	json, _ := json.MarshalIndent(s, "", "    ")
+	return string(json)
+}
+
+// Workspace is a named group of collections.
+type Workspace struct {
+	Title       string         `json:"title,omitempty"`
+	Collections []*Collection  `json:"collections,omitempty"`
+	Extensions  ext.Extensions `json:"extensions,omitempty"`
+}
+
+// Collection describes a collection of resources that can be listed,
+// and created by POSTing to Href.
+type Collection struct {
+	Href  string `json:"href,omitempty"`
+	Title string `json:"title,omitempty"`
+	// Accept lists the app:accept media ranges as written. An empty
+	// app:accept, meaning members cannot be created, is kept as "";
+	// see AcceptedMediaTypes.
+	Accept     []string       `json:"accept,omitempty"`
+	Categories []*Categories  `json:"categories,omitempty"`
+	Extensions ext.Extensions `json:"extensions,omitempty"`
+}
+
+// AcceptedMediaTypes returns the media ranges of the resources that can be
+// created in the collection. A collection without app:accept elements
+// accepts Atom entries, and one with only an empty app:accept accepts
+// nothing.
+func (c *Collection) AcceptedMediaTypes() []string {
+	if c.Accept == nil {
+		return []string{entryMediaType}
+	}
+	var types []string
+	for _, accept := range c.Accept {
+		if accept != "" {
+			types = append(types, accept)
+		}
+	}
+	return types
+}
+
+// Categories is an app:categories element, or a standalone category
+// document. Its categories are either listed inline or, when Href is set,
+// kept out-of-line in the category document at Href.
+type Categories struct {
+	Href string `json:"href,omitempty"`
+	// Fixed reports that members may only use the listed categories.
+	Fixed  bool   `json:"fixed,omitempty"`
+	Scheme string `json:"scheme,omitempty"`
+	// Categories are the listed categories. Those without a scheme of
+	// their own are given Scheme.
+	Categories []*atom.Category `json:"categories,omitempty"`
+}
+
+func (c Categories) String() string {
+	json, _ := json.MarshalIndent(c, "", "    ")
+	return string(json)
+}
+
+// OutOfLine reports whether the categories are kept in a separate
+// category document, at Href.
+func (c *Categories) OutOfLine() bool {
+	return c.Href != ""
+}
@@ -0,0 +1,155 @@
+package gofeed
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// ContentHint tells what a document looks like.
+type ContentHint int
+
+const (
+	// ContentUnknown is a document Detect could not identify
+	ContentUnknown ContentHint = iota
+	// ContentFeed is a feed in one of the registered formats
+	ContentFeed
+	// ContentHTML is a web page, which may link to its feeds
+	ContentHTML
+	// ContentOPML is an OPML outline, such as a subscription list
+	ContentOPML
+	// ContentSitemap is an XML sitemap or sitemap index
+	ContentSitemap
+	// ContentErrorPage is an error response: an HTML page titled as
+	// an error, or a JSON error object
+	ContentErrorPage
+)
+
+func (h ContentHint) String() string {
+	switch h {
+	case ContentFeed:
+		return "feed"
+	case ContentHTML:
+		return "html"
+	case ContentOPML:
+		return "opml"
+	case ContentSitemap:
+		return "sitemap"
+	case ContentErrorPage:
+		return "error page"
+	}
+	return "unknown"
+}
+
+// Detection is what Detect learned about a document.
+type Detection struct {
+	// Type is the feed type, or FeedTypeUnknown when the document is not
+	// a feed.
+	Type FeedType
+	// Format is the name the feed's format is registered under, such as
+	// "rss" or "atom".
+	Format string
+	// Version is the version of the feed's format: 0.9, 0.91, 0.92, 1.0
+	// (RDF) or 2.0 for RSS, 0.3 or 1.0 for Atom, 1.0 or 1.1 for JSON Feed
+	// and 2.0 for ActivityStreams. It is "" when unknown.
+	Version string
+	// Encoding is the character encoding the document declares, in an XML
+	// declaration, an HTML meta element or a byte order mark, lowercased.
+	// It is "" when none is declared.
+	Encoding string
+	// Content is ContentFeed for a feed, and otherwise tells what the
+	// document looks like instead.
+	Content ContentHint
+}
+
+var (
+	// xmlEncodingPattern matches the encoding of an XML declaration.
+	xmlEncodingPattern = regexp.MustCompile(`^<\?xml[^>]*\sencoding\s*=\s*["']([A-Za-z0-9._:-]+)["']`)
+	// metaCharsetPattern matches the charset of an HTML meta element,
+	// whether given by the charset attribute or in an http-equiv
+	// Content-Type.
+	metaCharsetPattern = regexp.MustCompile(`(?i)<meta\s[^>]*charset\s*=\s*["']?([A-Za-z0-9._:-]+)`)
+	// titlePattern matches the title of an HTML page.
+	titlePattern = regexp.MustCompile(`(?is)<title[^>]*>(.*?)</title`)
+	// errorTitlePattern matches the titles of error pages, such as
+	// "404 Not Found", "Error: ..." or "Access Denied".
+	errorTitlePattern = regexp.MustCompile(`(?i)^\s*(error(\s+[45]\d\d)?\s*($|[:|-])|[45]\d\d\b)|\b(not found|forbidden|access denied|unauthorized|internal server error|bad gateway|service unavailable|gateway time-?out|too many requests)\b`)
+)
+
+// Detect determines what a document is from its first few KB, like
+// DetectFeedType, but also reports the version and declared encoding of a
+// feed, and for anything else, whether it looks like a web page, an OPML
+// outline, a sitemap or an error page. A reader that fails before that is
+// reported as unknown.
+func Detect(r io.Reader) Detection {
+	buffer := new(bytes.Buffer)
+	if _, err := buffer.ReadFrom(io.LimitReader(r, detectionPeekSize)); err != nil {
+		return Detection{}
+	}
+	return detect(buffer.Bytes())
+}
+
+// detect describes a document from its prefix.
+func detect(prefix []byte) Detection {
+	d := Detection{Encoding: declaredEncoding(prefix)}
+	if format := detectFormat(prefix); format != nil {
+		d.Type = format.Type
+		d.Format = format.Name
+		d.Content = ContentFeed
+		if format.Version != nil {
+			d.Version = format.Version(prefix)
+		}
+		return d
+	}
+	d.Content = contentHint(prefix)
+	return d
+}
+
+// declaredEncoding returns the encoding a document declares.
+func declaredEncoding(prefix []byte) string {
+	switch {
+	case bytes.HasPrefix(prefix, []byte{0xEF, 0xBB, 0xBF}):
+		return "utf-8"
+	case bytes.HasPrefix(prefix, []byte{0xFE, 0xFF}):
+		return "utf-16be"
+	case bytes.HasPrefix(prefix, []byte{0xFF, 0xFE}):
+		return "utf-16le"
+	}
+	data := trimLeading(prefix)
+	if m := xmlEncodingPattern.FindSubmatch(data); m != nil {
+		return strings.ToLower(string(m[1]))
+	}
+	if firstChar(data) == '<' {
+		if m := metaCharsetPattern.FindSubmatch(data); m != nil {
+			return strings.ToLower(string(m[1]))
+		}
+	}
+	return ""
+}
+
+// contentHint tells what a document that is not a feed looks like.
+func contentHint(prefix []byte) ContentHint {
+	switch firstChar(prefix) {
+	case '<':
+		switch xmlRootName(prefix) {
+		case "opml":
+			return ContentOPML
+		case "urlset", "sitemapindex":
+			return ContentSitemap
+		}
+		if htmlPattern.Match(prefix) {
+			if m := titlePattern.FindSubmatch(prefix); m != nil && errorTitlePattern.Match(m[1]) {
+				return ContentErrorPage
+			}
+			return ContentHTML
+		}
+	case '{':
+		var err json.RawMessage
+		if jsonMember(prefix, "error", &err) || jsonMember(prefix, "errors", &err) {
+			return ContentErrorPage
+		}
+	}
+	return ContentUnknown
+}
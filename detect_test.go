@@ -0,0 +1,108 @@
+package gofeed_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/mmcdole/gofeed"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetect(t *testing.T) {
+	var detectTests = []struct {
+		file     string
+		expected gofeed.Detection
+	}{
+		{"atom03_feed.xml", gofeed.Detection{Type: gofeed.FeedTypeAtom, Format: "atom", Version: "0.3", Content: gofeed.ContentFeed}},
+		{"atom10_feed.xml", gofeed.Detection{Type: gofeed.FeedTypeAtom, Format: "atom", Version: "1.0", Content: gofeed.ContentFeed}},
+		{"rss_feed.xml", gofeed.Detection{Type: gofeed.FeedTypeRSS, Format: "rss", Version: "2.0", Content: gofeed.ContentFeed}},
+		{"rdf_feed.xml", gofeed.Detection{Type: gofeed.FeedTypeRSS, Format: "rss", Version: "1.0", Content: gofeed.ContentFeed}},
+		{"json10_feed.json", gofeed.Detection{Type: gofeed.FeedTypeJSON, Format: "json", Version: "1.0", Content: gofeed.ContentFeed}},
+		{"json11_feed.json", gofeed.Detection{Type: gofeed.FeedTypeJSON, Format: "json", Version: "1.1", Content: gofeed.ContentFeed}},
+		{"hfeed.html", gofeed.Detection{Type: gofeed.FeedTypeHFeed, Format: "hfeed", Encoding: "utf-8", Content: gofeed.ContentFeed}},
+		{"activitystreams_outbox.json", gofeed.Detection{Type: gofeed.FeedTypeActivityStreams, Format: "activitystreams", Version: "2.0", Content: gofeed.ContentFeed}},
+		{"html_page.html", gofeed.Detection{Encoding: "utf-8", Content: gofeed.ContentHTML}},
+		{"api_error.json", gofeed.Detection{Content: gofeed.ContentErrorPage}},
+		{"empty_feed.xml", gofeed.Detection{}},
+	}
+
+	for _, test := range detectTests {
+		fmt.Printf("Testing %s... ", test.file)
+
+		// Get feed content
+		path := fmt.Sprintf("testdata/parser/universal/%s", test.file)
+		f, _ := os.ReadFile(path)
+
+		if assert.Equal(t, test.expected, gofeed.Detect(strings.NewReader(string(f))), "File %s", test.file) {
+			fmt.Printf("OK\n")
+		} else {
+			fmt.Printf("Failed\n")
+		}
+	}
+}
+
+func TestDetect_Documents(t *testing.T) {
+	var detectTests = []struct {
+		name     string
+		doc      string
+		expected gofeed.Detection
+	}{
+		{"rss 0.91", `<?xml version="1.0" encoding="ISO-8859-1"?><rss version="0.91"><channel></channel></rss>`,
+			gofeed.Detection{Type: gofeed.FeedTypeRSS, Format: "rss", Version: "0.91", Encoding: "iso-8859-1", Content: gofeed.ContentFeed}},
+		{"rss 0.92", `<rss version="0.92"><channel></channel></rss>`,
+			gofeed.Detection{Type: gofeed.FeedTypeRSS, Format: "rss", Version: "0.92", Content: gofeed.ContentFeed}},
+		{"rss 0.90", `<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#" xmlns="http://my.netscape.com/rdf/simple/0.9/"></rdf:RDF>`,
+			gofeed.Detection{Type: gofeed.FeedTypeRSS, Format: "rss", Version: "0.9", Content: gofeed.ContentFeed}},
+		{"utf-8 bom", "\xEF\xBB\xBF<feed xmlns=\"http://www.w3.org/2005/Atom\"></feed>",
+			gofeed.Detection{Type: gofeed.FeedTypeAtom, Format: "atom", Version: "1.0", Encoding: "utf-8", Content: gofeed.ContentFeed}},
+		{"opml", `<?xml version="1.0" encoding="UTF-8"?><opml version="2.0"><head><title>Subscriptions</title></head><body></body></opml>`,
+			gofeed.Detection{Encoding: "utf-8", Content: gofeed.ContentOPML}},
+		{"sitemap", `<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9"><url><loc>https://example.org/</loc></url></urlset>`,
+			gofeed.Detection{Content: gofeed.ContentSitemap}},
+		{"sitemap index", `<sitemapindex xmlns="http://www.sitemaps.org/schemas/sitemap/0.9"></sitemapindex>`,
+			gofeed.Detection{Content: gofeed.ContentSitemap}},
+		{"html", `<!DOCTYPE html><html><head><meta http-equiv="Content-Type" content="text/html; charset=Windows-1252"><title>Error Handling in Go</title></head></html>`,
+			gofeed.Detection{Encoding: "windows-1252", Content: gofeed.ContentHTML}},
+		{"html 404", `<!DOCTYPE html><html><head><title>404 Not Found</title></head><body><h1>Not Found</h1></body></html>`,
+			gofeed.Detection{Content: gofeed.ContentErrorPage}},
+		{"html error", `<html><head><title>Error: this page is no longer available</title></head></html>`,
+			gofeed.Detection{Content: gofeed.ContentErrorPage}},
+		{"html access denied", `<html><head><title>Access Denied | Example</title></head></html>`,
+			gofeed.Detection{Content: gofeed.ContentErrorPage}},
+		{"json errors", `{"errors": [{"status": "404", "title": "Not Found"}]}`,
+			gofeed.Detection{Content: gofeed.ContentErrorPage}},
+		{"json", `{"name": "not a feed"}`, gofeed.Detection{}},
+		{"text", `just some text`, gofeed.Detection{}},
+	}
+
+	for _, test := range detectTests {
+		assert.Equal(t, test.expected, gofeed.Detect(strings.NewReader(test.doc)), test.name)
+	}
+}
+
+func TestDetect_ReaderError(t *testing.T) {
+	r := io.MultiReader(strings.NewReader(`<rss version="2.0"></rss>`), iotest.ErrReader(errors.New("boom")))
+	assert.Equal(t, gofeed.Detection{}, gofeed.Detect(r))
+}
+
+func TestContentHint_String(t *testing.T) {
+	assert.Equal(t, "html", gofeed.ContentHTML.String())
+	assert.Equal(t, "error page", gofeed.ContentErrorPage.String())
+	assert.Equal(t, "unknown", gofeed.ContentHint(99).String())
+}
+
+// Examples
+
+func ExampleDetect() {
+	page := `<!DOCTYPE html><html><head><title>Example</title></head><body></body></html>`
+	d := gofeed.Detect(strings.NewReader(page))
+	if d.Content == gofeed.ContentHTML {
+		fmt.Println("This is a web page, searching for feeds")
+	}
+	// Output: This is a web page, searching for feeds
+}
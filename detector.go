@@ -4,9 +4,11 @@ import (
 	"bytes"
 	"encoding/json"
 	"io"
+	"regexp"
 	"strings"
 
 	"github.com/mmcdole/gofeed/internal/shared"
+	xpp "github.com/mmcdole/goxpp/v2"
 )
 
 // FeedType represents one of the possible feed
@@ -23,61 +25,228 @@ const (
 	FeedTypeRSS
 	// FeedTypeJSON represents a JSON feed
 	FeedTypeJSON
+	// FeedTypeHFeed represents an HTML page marked up
+	// with the h-feed or h-entry microformats
+	FeedTypeHFeed
+	// FeedTypeActivityStreams represents an ActivityStreams 2.0
+	// collection, such as an ActivityPub outbox
+	FeedTypeActivityStreams
+)
+
+var (
+	// htmlPattern matches the start of an HTML document.
+	htmlPattern = regexp.MustCompile(`(?i)<(!doctype\s+html|html)[\s>]`)
+	// hfeedPattern matches a class attribute holding an h-feed or h-entry
+	// class.
+	hfeedPattern = regexp.MustCompile(`(?i)\bclass\s*=\s*["']?[^"'>]*\bh-(feed|entry)\b`)
 )
 
 // DetectFeedType attempts to determine the type of feed
-// by looking for specific xml elements unique to the
-// various feed types. It returns FeedTypeUnknown when the
-// reader fails before the type can be determined.
+// by consulting the registered formats' detectors in
+// priority order; see RegisterFormat. Like Parser.Parse, it
+// only reads the first few KB of the reader. It returns
+// FeedTypeUnknown when no format matches, or when the reader
+// fails before the type can be determined. Detect tells more
+// about the document.
 func DetectFeedType(feed io.Reader) FeedType {
-	buffer := new(bytes.Buffer)
-	if _, err := buffer.ReadFrom(feed); err != nil {
-		return FeedTypeUnknown
-	}
+	return Detect(feed).Type
+}
 
-	var firstChar byte
-loop:
-	for {
-		ch, err := buffer.ReadByte()
-		if err != nil {
-			return FeedTypeUnknown
-		}
-		// ignore leading whitespace & byte order marks
+// trimLeading returns data from its first character, skipping leading
+// whitespace and byte order marks.
+func trimLeading(data []byte) []byte {
+	for i, ch := range data {
 		switch ch {
 		case ' ', '\r', '\n', '\t':
 		case 0xFE, 0xFF, 0x00, 0xEF, 0xBB, 0xBF: // utf 8-16-32 bom
 		default:
-			firstChar = ch
-			buffer.UnreadByte()
-			break loop
+			return data[i:]
 		}
 	}
+	return nil
+}
+
+// firstChar returns the first character of data, or 0 for a blank
+// document.
+func firstChar(data []byte) byte {
+	if data = trimLeading(data); len(data) > 0 {
+		return data[0]
+	}
+	return 0
+}
 
-	if firstChar == '<' {
-		// Check if it's an XML based feed
-		p := shared.NewXMLParser(bytes.NewReader(buffer.Bytes()))
+// xmlRoot returns a parser positioned on the root element of an XML
+// document, or nil when data does not start one.
+func xmlRoot(data []byte) *xpp.Parser {
+	data = trimLeading(data)
+	if firstChar(data) != '<' {
+		return nil
+	}
+	p := shared.NewXMLParser(bytes.NewReader(data))
+	if _, err := shared.FindRoot(p); err != nil {
+		return nil
+	}
+	return p
+}
 
-		_, err := shared.FindRoot(p)
-		if err != nil {
-			return FeedTypeUnknown
+// xmlRootName returns the lowercased name of the root element of an XML
+// document, or "" when data does not start one.
+func xmlRootName(data []byte) string {
+	if p := xmlRoot(data); p != nil {
+		return strings.ToLower(p.Name())
+	}
+	return ""
+}
+
+// detectRSS detects RSS 0.9x and 2.0 and RSS 1.0 (RDF) feeds.
+func detectRSS(prefix []byte) bool {
+	switch xmlRootName(prefix) {
+	case "rss", "rdf":
+		return true
+	}
+	return false
+}
+
+// detectAtom detects Atom 0.3 and 1.0 feeds.
+func detectAtom(prefix []byte) bool {
+	return xmlRootName(prefix) == "feed"
+}
+
+// rssVersion returns the version of an RSS feed: that of its version
+// attribute, or for RDF feeds 0.9 or 1.0 by their namespace.
+func rssVersion(prefix []byte) string {
+	p := xmlRoot(prefix)
+	if p == nil {
+		return ""
+	}
+	if strings.EqualFold(p.Name(), "rss") {
+		return p.Attribute("version")
+	}
+	switch p.Attribute("xmlns") {
+	case "http://channel.netscape.com/rdf/simple/0.9/", "http://my.netscape.com/rdf/simple/0.9/":
+		return "0.9"
+	case "http://purl.org/rss/1.0/":
+		return "1.0"
+	}
+	return ""
+}
+
+// atomVersion returns the version of an Atom feed, 0.3 or 1.0.
+func atomVersion(prefix []byte) string {
+	p := xmlRoot(prefix)
+	if p == nil {
+		return ""
+	}
+	if version := p.Attribute("version"); version != "" {
+		return version
+	}
+	switch p.Attribute("xmlns") {
+	case "http://purl.org/atom/ns#":
+		return "0.3"
+	case "http://www.w3.org/2005/Atom":
+		return "1.0"
+	}
+	return ""
+}
+
+// detectHFeed detects an HTML document using the h-feed or h-entry
+// classes. HTML is frequently not well-formed XML, so the root element is
+// not required to parse.
+func detectHFeed(prefix []byte) bool {
+	return firstChar(prefix) == '<' && htmlPattern.Match(prefix) && hfeedPattern.Match(prefix)
+}
+
+// jsonFeedVersionPrefix starts the version URL of every JSON Feed version.
+const jsonFeedVersionPrefix = "https://jsonfeed.org/version/"
+
+// detectJSON detects a JSON Feed by its version, so that other JSON, such
+// as the error payload of an API, is not mistaken for an empty feed.
+//
+// Only the prefix is looked at, so a JSON Feed whose version comes after
+// more than the prefix holds is not detected from its content; served as
+// application/feed+json, it is still parsed, as labeledJSON allows.
+func detectJSON(prefix []byte) bool {
+	var version string
+	return jsonMember(prefix, "version", &version) && strings.HasPrefix(version, jsonFeedVersionPrefix)
+}
+
+// jsonFeedVersion returns the version of a JSON Feed, 1.0 or 1.1.
+func jsonFeedVersion(prefix []byte) string {
+	var version string
+	jsonMember(prefix, "version", &version)
+	version = strings.TrimPrefix(version, jsonFeedVersionPrefix)
+	if version == "1" {
+		return "1.0"
+	}
+	return version
+}
+
+// labeledXML accepts any XML document for a format the server labeled it
+// as, such as one whose root element lies beyond the detection prefix.
+func labeledXML(prefix []byte) bool {
+	return firstChar(prefix) == '<'
+}
+
+// labeledJSON accepts a JSON Feed labeled as one whose version is missing
+// or unknown, provided it has any of the members a feed is made of.
+func labeledJSON(prefix []byte) bool {
+	for _, key := range []string{"items", "feed_url", "home_page_url"} {
+		if jsonMember(prefix, key, nil) {
+			return true
 		}
+	}
+	return false
+}
 
-		name := strings.ToLower(p.Name())
-		switch name {
-		case "rdf":
-			return FeedTypeRSS
-		case "rss":
-			return FeedTypeRSS
-		case "feed":
-			return FeedTypeAtom
-		default:
-			return FeedTypeUnknown
+// labeledActivityStreams accepts a collection labeled as ActivityStreams
+// that omits the @context.
+func labeledActivityStreams(prefix []byte) bool {
+	var typ string
+	return jsonMember(prefix, "type", &typ) && strings.Contains(typ, "Collection")
+}
+
+// detectActivityStreams detects a JSON document declaring the
+// ActivityStreams 2.0 context.
+func detectActivityStreams(prefix []byte) bool {
+	var context interface{}
+	if !jsonMember(prefix, "@context", &context) {
+		return false
+	}
+	contexts, ok := context.([]interface{})
+	if !ok {
+		contexts = []interface{}{context}
+	}
+	for _, context := range contexts {
+		// The context is also valid with the http scheme.
+		if s, ok := context.(string); ok && strings.HasSuffix(s, "://www.w3.org/ns/activitystreams") {
+			return true
+		}
+	}
+	return false
+}
+
+// jsonMember streams the members of the top-level object of a JSON
+// document, decoding the value of the first one named key into v. It
+// reports whether it did; the document may be truncated anywhere past that
+// member, as a detection prefix usually is. With a nil v it only reports
+// whether the member is there, so its value may be truncated too.
+func jsonMember(data []byte, key string, v interface{}) bool {
+	dec := json.NewDecoder(bytes.NewReader(trimLeading(data)))
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return false
+	}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return false
+		}
+		if name, _ := tok.(string); name == key {
+			return v == nil || dec.Decode(v) == nil
 		}
-	} else if firstChar == '{' {
-		// Check if document is valid JSON
-		if json.Valid(buffer.Bytes()) {
-			return FeedTypeJSON
+		var skipped json.RawMessage
+		if err := dec.Decode(&skipped); err != nil {
+			return false
 		}
 	}
-	return FeedTypeUnknown
+	return false
 }
@@ -28,6 +28,12 @@ func TestDetectFeedType(t *testing.T) {
 		{"unknown_feed.xml", gofeed.FeedTypeUnknown},
 		{"empty_feed.xml", gofeed.FeedTypeUnknown},
 		{"json10_feed.json", gofeed.FeedTypeJSON},
+		{"json11_feed.json", gofeed.FeedTypeJSON},
+		{"api_error.json", gofeed.FeedTypeUnknown},
+		{"invalid.json", gofeed.FeedTypeUnknown},
+		{"hfeed.html", gofeed.FeedTypeHFeed},
+		{"activitystreams_outbox.json", gofeed.FeedTypeActivityStreams},
+		{"html_page.html", gofeed.FeedTypeUnknown},
 	}
 
 	for _, test := range feedTypeTests {
@@ -68,3 +74,85 @@ func TestDetectFeedType_ReaderError(t *testing.T) {
 	r := io.MultiReader(strings.NewReader(`<rss version="2.0"></rss>`), iotest.ErrReader(errors.New("boom")))
 	assert.Equal(t, gofeed.FeedTypeUnknown, gofeed.DetectFeedType(r))
 }
+
+// A JSON Feed is detected by its version member, wherever it sits in the
+// top-level object, and other JSON is not.
+func TestDetectFeedType_JSONVersion(t *testing.T) {
+	tests := []struct {
+		doc      string
+		expected gofeed.FeedType
+	}{
+		{`{"version": "https://jsonfeed.org/version/1.1", "items": []}`, gofeed.FeedTypeJSON},
+		{`{"title": "t", "items": [{"id": "1", "version": "x"}], "version": "https://jsonfeed.org/version/1"}`, gofeed.FeedTypeJSON},
+		{`{"items": [{"version": "https://jsonfeed.org/version/1"}]}`, gofeed.FeedTypeUnknown},
+		{`{"version": "1.0", "items": []}`, gofeed.FeedTypeUnknown},
+		{`{"version": 2}`, gofeed.FeedTypeUnknown},
+		{`["https://jsonfeed.org/version/1"]`, gofeed.FeedTypeUnknown},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.expected, gofeed.DetectFeedType(strings.NewReader(test.doc)), test.doc)
+	}
+}
+
+// Detection needs the version: a large document that starts like a JSON
+// Feed but has no version in the detection window is not taken for one,
+// unless it is served as a JSON Feed.
+func TestDetectFeedType_JSONVersionBeyondWindow(t *testing.T) {
+	var sb strings.Builder
+	sb.WriteString(`{"title": "big", "items": [`)
+	for i := 0; i < 200; i++ {
+		if i > 0 {
+			sb.WriteString(",")
+		}
+		fmt.Fprintf(&sb, `{"id": "%d", "content_text": "item %d"}`, i, i)
+	}
+	sb.WriteString(`], "version": "https://jsonfeed.org/version/1.1"}`)
+	assert.Greater(t, sb.Len(), 6<<10)
+
+	assert.Equal(t, gofeed.FeedTypeUnknown, gofeed.DetectFeedType(strings.NewReader(sb.String())))
+	_, err := gofeed.NewParser().ParseString(sb.String())
+	assert.ErrorIs(t, err, gofeed.ErrFeedTypeNotDetected)
+
+	feed, err := gofeed.NewParser().ParseWithContentType(strings.NewReader(sb.String()), "application/feed+json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "json", feed.FeedType)
+	assert.Len(t, feed.Items, 200)
+}
+
+// countingReader counts the bytes read through it.
+type countingReader struct {
+	r io.Reader
+	n int
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += n
+	return n, err
+}
+
+// Detection reads a bounded prefix, which is enough for a JSON Feed much
+// larger than it.
+func TestDetectFeedType_BoundedRead(t *testing.T) {
+	var sb strings.Builder
+	sb.WriteString(`{"version": "https://jsonfeed.org/version/1.1", "title": "big", "items": [`)
+	for i := 0; i < 2000; i++ {
+		if i > 0 {
+			sb.WriteString(",")
+		}
+		fmt.Fprintf(&sb, `{"id": "%d", "content_text": "item %d"}`, i, i)
+	}
+	sb.WriteString(`]}`)
+
+	r := &countingReader{r: strings.NewReader(sb.String())}
+	assert.Equal(t, gofeed.FeedTypeJSON, gofeed.DetectFeedType(r))
+	assert.LessOrEqual(t, r.n, 4096)
+
+	feed, err := gofeed.NewParser().ParseString(sb.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Len(t, feed.Items, 2000)
+}
@@ -0,0 +1,19 @@
+package ext
+
+// CreativeCommonsExtension represents a feed extension for
+// Creative Commons licensing, covering both the RSS 2.0
+// module (creativeCommons:license) and the RDF vocabulary
+// used in RSS 1.0 (cc:license).
+// http://backend.userland.com/creativeCommonsRssModule
+type CreativeCommonsExtension struct {
+	License []string `json:"license,omitempty"`
+}
+
+// NewCreativeCommonsExtension creates a new CreativeCommonsExtension
+// given the generic extension map for the "creativeCommons" or "cc"
+// prefix.
+func NewCreativeCommonsExtension(extensions map[string][]Extension) *CreativeCommonsExtension {
+	cc := &CreativeCommonsExtension{}
+	cc.License = parseResourceArrayExtension("license", extensions)
+	return cc
+}
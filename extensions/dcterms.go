@@ -0,0 +1,107 @@
+package ext
+
+// DCTermsExtension represents a feed extension for the
+// DCMI Metadata Terms (dcterms:), the successor to the
+// legacy Dublin Core elements. Terms that commonly point
+// at a resource (e.g. license, isPartOf) are read from the
+// element text or from an rdf:resource attribute.
+// https://www.dublincore.org/specifications/dublin-core/dcmi-terms/
+type DCTermsExtension struct {
+	Title                 []string `json:"title,omitempty"`
+	Alternative           []string `json:"alternative,omitempty"`
+	Creator               []string `json:"creator,omitempty"`
+	Subject               []string `json:"subject,omitempty"`
+	Description           []string `json:"description,omitempty"`
+	Abstract              []string `json:"abstract,omitempty"`
+	TableOfContents       []string `json:"tableOfContents,omitempty"`
+	Publisher             []string `json:"publisher,omitempty"`
+	Contributor           []string `json:"contributor,omitempty"`
+	Date                  []string `json:"date,omitempty"`
+	Created               []string `json:"created,omitempty"`
+	Issued                []string `json:"issued,omitempty"`
+	Modified              []string `json:"modified,omitempty"`
+	Available             []string `json:"available,omitempty"`
+	Valid                 []string `json:"valid,omitempty"`
+	DateAccepted          []string `json:"dateAccepted,omitempty"`
+	DateCopyrighted       []string `json:"dateCopyrighted,omitempty"`
+	DateSubmitted         []string `json:"dateSubmitted,omitempty"`
+	Type                  []string `json:"type,omitempty"`
+	Format                []string `json:"format,omitempty"`
+	Extent                []string `json:"extent,omitempty"`
+	Medium                []string `json:"medium,omitempty"`
+	Identifier            []string `json:"identifier,omitempty"`
+	BibliographicCitation []string `json:"bibliographicCitation,omitempty"`
+	Source                []string `json:"source,omitempty"`
+	Language              []string `json:"language,omitempty"`
+	Relation              []string `json:"relation,omitempty"`
+	IsPartOf              []string `json:"isPartOf,omitempty"`
+	HasPart               []string `json:"hasPart,omitempty"`
+	IsVersionOf           []string `json:"isVersionOf,omitempty"`
+	HasVersion            []string `json:"hasVersion,omitempty"`
+	IsReplacedBy          []string `json:"isReplacedBy,omitempty"`
+	Replaces              []string `json:"replaces,omitempty"`
+	IsReferencedBy        []string `json:"isReferencedBy,omitempty"`
+	References            []string `json:"references,omitempty"`
+	ConformsTo            []string `json:"conformsTo,omitempty"`
+	Coverage              []string `json:"coverage,omitempty"`
+	Spatial               []string `json:"spatial,omitempty"`
+	Temporal              []string `json:"temporal,omitempty"`
+	Audience              []string `json:"audience,omitempty"`
+	Rights                []string `json:"rights,omitempty"`
+	AccessRights          []string `json:"accessRights,omitempty"`
+	License               []string `json:"license,omitempty"`
+	RightsHolder          []string `json:"rightsHolder,omitempty"`
+	Provenance            []string `json:"provenance,omitempty"`
+}
+
+// NewDCTermsExtension creates a new DCTermsExtension
+// given the generic extension map for the "dcterms" prefix.
+func NewDCTermsExtension(extensions map[string][]Extension) *DCTermsExtension {
+	dc := &DCTermsExtension{}
+	dc.Title = parseTextArrayExtension("title", extensions)
+	dc.Alternative = parseTextArrayExtension("alternative", extensions)
+	dc.Creator = parseTextArrayExtension("creator", extensions)
+	dc.Subject = parseTextArrayExtension("subject", extensions)
+	dc.Description = parseTextArrayExtension("description", extensions)
+	dc.Abstract = parseTextArrayExtension("abstract", extensions)
+	dc.TableOfContents = parseTextArrayExtension("tableOfContents", extensions)
+	dc.Publisher = parseTextArrayExtension("publisher", extensions)
+	dc.Contributor = parseTextArrayExtension("contributor", extensions)
+	dc.Date = parseTextArrayExtension("date", extensions)
+	dc.Created = parseTextArrayExtension("created", extensions)
+	dc.Issued = parseTextArrayExtension("issued", extensions)
+	dc.Modified = parseTextArrayExtension("modified", extensions)
+	dc.Available = parseTextArrayExtension("available", extensions)
+	dc.Valid = parseTextArrayExtension("valid", extensions)
+	dc.DateAccepted = parseTextArrayExtension("dateAccepted", extensions)
+	dc.DateCopyrighted = parseTextArrayExtension("dateCopyrighted", extensions)
+	dc.DateSubmitted = parseTextArrayExtension("dateSubmitted", extensions)
+	dc.Type = parseResourceArrayExtension("type", extensions)
+	dc.Format = parseResourceArrayExtension("format", extensions)
+	dc.Extent = parseTextArrayExtension("extent", extensions)
+	dc.Medium = parseResourceArrayExtension("medium", extensions)
+	dc.Identifier = parseTextArrayExtension("identifier", extensions)
+	dc.BibliographicCitation = parseTextArrayExtension("bibliographicCitation", extensions)
+	dc.Source = parseResourceArrayExtension("source", extensions)
+	dc.Language = parseResourceArrayExtension("language", extensions)
+	dc.Relation = parseResourceArrayExtension("relation", extensions)
+	dc.IsPartOf = parseResourceArrayExtension("isPartOf", extensions)
+	dc.HasPart = parseResourceArrayExtension("hasPart", extensions)
+	dc.IsVersionOf = parseResourceArrayExtension("isVersionOf", extensions)
+	dc.HasVersion = parseResourceArrayExtension("hasVersion", extensions)
+	dc.IsReplacedBy = parseResourceArrayExtension("isReplacedBy", extensions)
+	dc.Replaces = parseResourceArrayExtension("replaces", extensions)
+	dc.IsReferencedBy = parseResourceArrayExtension("isReferencedBy", extensions)
+	dc.References = parseResourceArrayExtension("references", extensions)
+	dc.ConformsTo = parseResourceArrayExtension("conformsTo", extensions)
+	dc.Coverage = parseTextArrayExtension("coverage", extensions)
+	dc.Spatial = parseResourceArrayExtension("spatial", extensions)
+	dc.Temporal = parseTextArrayExtension("temporal", extensions)
+	dc.Audience = parseResourceArrayExtension("audience", extensions)
+	dc.Rights = parseTextArrayExtension("rights", extensions)
+	dc.AccessRights = parseResourceArrayExtension("accessRights", extensions)
+	dc.License = parseResourceArrayExtension("license", extensions)
+	dc.RightsHolder = parseResourceArrayExtension("rightsHolder", extensions)
+	dc.Provenance = parseTextArrayExtension("provenance", extensions)
+	return dc
+}
@@ -0,0 +1,200 @@
+package ext
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Decode stores the elements in the namespace URI ns into the struct
+// pointed to by v, the way encoding/xml unmarshals a document. For
+// extensions that carry no namespace URI, such as JSON Feed extension
+// objects, ns may instead be the key they are stored under. v is left
+// untouched when there are no such elements.
+//
+// Struct fields are mapped with "feed" tags:
+//
+//	Title   string    `feed:"title"`           // text of the title element
+//	Links   []string  `feed:"link"`            // text of every link element
+//	Owner   *Owner    `feed:"owner"`           // a child element, decoded recursively
+//	Href    string    `feed:"href,attr"`       // an attribute of the element
+//	Text    string    `feed:",chardata"`       // the text of the element itself
+//	Raw     Extension `feed:"thing"`           // the element as parsed
+//	Skipped string    `feed:"-"`
+//
+// Untagged exported fields match elements with the same name as the field.
+// Fields may be strings, bools, numbers, types implementing
+// encoding.TextUnmarshaler (such as time.Time), structs, Extension, or
+// pointers and slices of those.
+func (e Extensions) Decode(ns string, v interface{}) error {
+	elements := e.Namespace(ns)
+	if elements == nil {
+		elements = e[ns]
+	}
+	if elements == nil {
+		return nil
+	}
+	return decodeRoot(Extension{Namespace: ns, Children: elements}, v)
+}
+
+// Decode stores the extension element e into the struct pointed to by v.
+// Attributes and the element's text are available through attr and
+// chardata fields; see Extensions.Decode.
+func (e Extension) Decode(v interface{}) error {
+	return decodeRoot(e, v)
+}
+
+func decodeRoot(e Extension, v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return errors.New("ext: Decode requires a non-nil pointer")
+	}
+	return decodeElement(rv.Elem(), e)
+}
+
+var (
+	extensionType       = reflect.TypeOf(Extension{})
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// decodeElement stores e into v.
+func decodeElement(v reflect.Value, e Extension) error {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return decodeElement(v.Elem(), e)
+	}
+	if v.Type() == extensionType {
+		v.Set(reflect.ValueOf(e))
+		return nil
+	}
+	if v.Kind() == reflect.Struct && !v.Addr().Type().Implements(textUnmarshalerType) {
+		return decodeStruct(v, e)
+	}
+	return decodeText(v, e.Value)
+}
+
+func decodeStruct(v reflect.Value, e Extension) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, opts := field.Name, ""
+		if tag, ok := field.Tag.Lookup("feed"); ok {
+			if tag == "-" {
+				continue
+			}
+			name, opts, _ = strings.Cut(tag, ",")
+		}
+
+		fv := v.Field(i)
+		var err error
+		switch opts {
+		case "attr":
+			if name == "" {
+				name = field.Name
+			}
+			if value, ok := e.Attrs[localName(name)]; ok {
+				err = decodeText(fv, value)
+			}
+		case "chardata":
+			err = decodeText(fv, e.Value)
+		case "":
+			if name == "" {
+				name = field.Name
+			}
+			err = decodeChildren(fv, e.Children[localName(name)])
+		default:
+			err = fmt.Errorf("unknown option %q", opts)
+		}
+		if err != nil {
+			return fmt.Errorf("ext: decoding field %s.%s: %w", t.Name(), field.Name, err)
+		}
+	}
+	return nil
+}
+
+// decodeChildren stores the child elements of one name into v: all of them
+// for slices, the first otherwise.
+func decodeChildren(v reflect.Value, children []Extension) error {
+	if len(children) == 0 {
+		return nil
+	}
+	if v.Kind() == reflect.Slice && v.Type().Elem().Kind() != reflect.Uint8 {
+		slice := reflect.MakeSlice(v.Type(), len(children), len(children))
+		for i, child := range children {
+			if err := decodeElement(slice.Index(i), child); err != nil {
+				return err
+			}
+		}
+		v.Set(slice)
+		return nil
+	}
+	return decodeElement(v, children[0])
+}
+
+// decodeText stores text into a scalar, pointer or
+// encoding.TextUnmarshaler v. Empty text leaves numbers and bools unset.
+func decodeText(v reflect.Value, text string) error {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return decodeText(v.Elem(), text)
+	}
+	if v.CanAddr() && v.Addr().Type().Implements(textUnmarshalerType) {
+		return v.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(text))
+	}
+
+	text = strings.TrimSpace(text)
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(text)
+		return nil
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			v.SetBytes([]byte(text))
+			return nil
+		}
+	}
+	if text == "" {
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		b, err := strconv.ParseBool(text)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(text, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(text, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(text, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(n)
+	default:
+		return fmt.Errorf("cannot decode text into %s", v.Type())
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package ext_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mmcdole/gofeed"
+	ext "github.com/mmcdole/gofeed/extensions"
+	"github.com/stretchr/testify/assert"
+)
+
+type shopPrice struct {
+	Currency string  `feed:"currency,attr"`
+	Amount   float64 `feed:",chardata"`
+}
+
+type shopItem struct {
+	SKU       string        `feed:"sku"`
+	Price     *shopPrice    `feed:"price"`
+	Tags      []string      `feed:"tag"`
+	Stock     int           `feed:"stock"`
+	OnSale    bool          `feed:"onSale"`
+	Available time.Time     `feed:"available"`
+	Variants  []shopVariant `feed:"variant"`
+	Raw       ext.Extension `feed:"sku"`
+	Ignored   string        `feed:"-"`
+}
+
+type shopVariant struct {
+	ID    string `feed:"id,attr"`
+	Color string `feed:"color"`
+}
+
+func TestExtensions_Decode(t *testing.T) {
+	feed := `<rss version="2.0" xmlns:s="http://example.com/ns/shop"><channel><item>
+		<s:sku>ABC-1</s:sku>
+		<s:price currency="EUR">9.50</s:price>
+		<s:tag>red</s:tag>
+		<s:tag>sale</s:tag>
+		<s:stock>12</s:stock>
+		<s:onSale>true</s:onSale>
+		<s:available>2026-03-01T10:00:00Z</s:available>
+		<s:variant id="v1"><s:color>red</s:color></s:variant>
+		<s:variant id="v2"><s:color>blue</s:color></s:variant>
+	</item></channel></rss>`
+
+	f, err := gofeed.NewParser().ParseString(feed)
+	assert.NoError(t, err)
+
+	var item shopItem
+	item.Ignored = "kept"
+	assert.NoError(t, f.Items[0].Extensions.Decode("http://example.com/ns/shop", &item))
+
+	assert.Equal(t, "ABC-1", item.SKU)
+	assert.Equal(t, &shopPrice{Currency: "EUR", Amount: 9.5}, item.Price)
+	assert.Equal(t, []string{"red", "sale"}, item.Tags)
+	assert.Equal(t, 12, item.Stock)
+	assert.True(t, item.OnSale)
+	assert.True(t, item.Available.Equal(time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)))
+	assert.Equal(t, []shopVariant{{ID: "v1", Color: "red"}, {ID: "v2", Color: "blue"}}, item.Variants)
+	assert.Equal(t, "sku", item.Raw.Name)
+	assert.Equal(t, "kept", item.Ignored)
+
+	// A single element decodes on its own.
+	var variant shopVariant
+	assert.NoError(t, item.Raw.Decode(&variant))
+	assert.NoError(t, f.Items[0].Extensions["s"]["variant"][1].Decode(&variant))
+	assert.Equal(t, shopVariant{ID: "v2", Color: "blue"}, variant)
+
+	// Missing namespaces leave v alone; bad values and targets are errors.
+	other := shopItem{SKU: "unchanged"}
+	assert.NoError(t, f.Items[0].Extensions.Decode("http://example.com/ns/none", &other))
+	assert.Equal(t, "unchanged", other.SKU)
+
+	var bad struct {
+		SKU int `feed:"sku"`
+	}
+	assert.Error(t, f.Items[0].Extensions.Decode("http://example.com/ns/shop", &bad))
+	assert.Error(t, f.Items[0].Extensions.Decode("http://example.com/ns/shop", item))
+}
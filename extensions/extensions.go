@@ -3,15 +3,77 @@ package ext
 // Extensions is the generic extension map for Feeds and Items.
 // The first map is for the element namespace prefix (e.g., itunes).
 // The second map is for the element name (e.g., author).
+//
+// Well-known namespaces are stored under their canonical prefix whatever
+// prefix the feed binds them to; other namespaces are stored under the
+// feed's own prefix. Use Namespace to look elements up by namespace URI.
 type Extensions map[string]map[string][]Extension
 
 // Extension represents a single XML element that was in a non
 // default namespace in a Feed or Item/Entry.
 type Extension struct {
-	Name     string                 `json:"name"`
-	Value    string                 `json:"value"`
-	Attrs    map[string]string      `json:"attrs"`
-	Children map[string][]Extension `json:"children"`
+	Name string `json:"name"`
+	// Namespace is the namespace URI of the element, or its prefix when
+	// the feed never declared one.
+	Namespace string            `json:"namespace,omitempty"`
+	Value     string            `json:"value"`
+	Attrs     map[string]string `json:"attrs"`
+	// AttrsNS holds the attributes that are in a namespace, keyed by
+	// namespace URI and then local name. They also appear in Attrs under
+	// their local name.
+	AttrsNS  map[string]map[string]string `json:"attrsNS,omitempty"`
+	Children map[string][]Extension       `json:"children"`
+	// InnerXML and Content are only set when the parser is configured to
+	// preserve extension XML. InnerXML is the markup between the element's
+	// start and end tags exactly as written, and Content holds its text,
+	// comments and child elements in document order.
+	InnerXML string `json:"innerXML,omitempty"`
+	Content  []Node `json:"content,omitempty"`
+}
+
+// NodeType identifies the kind of a Node.
+type NodeType string
+
+// The kinds of content an extension element may hold.
+const (
+	ElementNode NodeType = "element"
+	TextNode    NodeType = "text"
+	CommentNode NodeType = "comment"
+)
+
+// Node is one item of an extension element's content: a child element, a
+// run of text, or a comment.
+type Node struct {
+	Type    NodeType   `json:"type"`
+	Text    string     `json:"text,omitempty"`
+	Element *Extension `json:"element,omitempty"`
+}
+
+// Namespace returns the elements in the namespace URI ns, keyed by element
+// name, regardless of the prefix they are stored under. Elements from every
+// prefix bound to ns are merged. It returns nil when there are none.
+func (e Extensions) Namespace(ns string) map[string][]Extension {
+	var result map[string][]Extension
+	for _, prefix := range sortedKeys(e) {
+		for name, elements := range e[prefix] {
+			for _, el := range elements {
+				if el.Namespace != ns {
+					continue
+				}
+				if result == nil {
+					result = map[string][]Extension{}
+				}
+				result[name] = append(result[name], el)
+			}
+		}
+	}
+	return result
+}
+
+// AttrNS returns the value of the attribute name in the namespace URI ns.
+func (e Extension) AttrNS(ns, name string) (value string, ok bool) {
+	value, ok = e.AttrsNS[ns][name]
+	return
 }
 
 func parseTextExtension(name string, extensions map[string][]Extension) (value string) {
@@ -44,3 +106,27 @@ func parseTextArrayExtension(name string, extensions map[string][]Extension) (va
 	}
 	return
 }
+
+// parseResourceArrayExtension returns the values of the named elements,
+// taking each from its text or, for RDF style empty elements, from its
+// rdf:resource attribute.
+func parseResourceArrayExtension(name string, extensions map[string][]Extension) (values []string) {
+	if extensions == nil {
+		return
+	}
+
+	matches, ok := extensions[name]
+	if !ok || len(matches) == 0 {
+		return
+	}
+
+	values = []string{}
+	for _, m := range matches {
+		if m.Value != "" {
+			values = append(values, m.Value)
+		} else if res := m.Attrs["resource"]; res != "" {
+			values = append(values, res)
+		}
+	}
+	return
+}
@@ -10,6 +10,7 @@ import (
 	"testing"
 
 	"github.com/mmcdole/gofeed"
+	ext "github.com/mmcdole/gofeed/extensions"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -108,3 +109,86 @@ func TestDublinCore_Extensions(t *testing.T) {
 		}
 	}
 }
+
+type geoPoint struct {
+	Lat, Long string
+}
+
+func TestRegister_CustomNamespace(t *testing.T) {
+	ext.Register("http://example.com/ns/geo-test#", "geotest", func(exts map[string][]ext.Extension) *geoPoint {
+		p := &geoPoint{}
+		if v := exts["lat"]; len(v) > 0 {
+			p.Lat = v[0].Value
+		}
+		if v := exts["long"]; len(v) > 0 {
+			p.Long = v[0].Value
+		}
+		return p
+	})
+
+	// The feed's own prefix is replaced by the registered one.
+	feed := `<rss version="2.0" xmlns:g="http://example.com/ns/geo-test#"><channel>
+		<item><title>here</title><g:lat>52.5</g:lat><g:long>13.4</g:long></item>
+		<item><title>nowhere</title></item>
+	</channel></rss>`
+
+	f, err := gofeed.NewParser().ParseString(feed)
+	assert.NoError(t, err)
+
+	p, ok := ext.Get[*geoPoint](f.Items[0].Extensions)
+	if assert.True(t, ok) {
+		assert.Equal(t, &geoPoint{Lat: "52.5", Long: "13.4"}, p)
+	}
+
+	_, ok = ext.Get[*geoPoint](f.Items[1].Extensions)
+	assert.False(t, ok)
+}
+
+type trackback struct {
+	Ping string
+}
+
+// A namespace gofeed knows by a canonical prefix ("trackback" here) is
+// stored under the prefix it is registered with, where Get finds it.
+func TestRegister_RenamedCanonicalPrefix(t *testing.T) {
+	ext.Register("http://madskills.com/public/xml/rss/module/trackback/", "tb", func(exts map[string][]ext.Extension) *trackback {
+		tb := &trackback{}
+		if v := exts["ping"]; len(v) > 0 {
+			tb.Ping = v[0].Value
+		}
+		return tb
+	})
+
+	feed := `<rss version="2.0" xmlns:trackback="http://madskills.com/public/xml/rss/module/trackback/"><channel>
+		<item><title>pinged</title><trackback:ping>http://example.com/tb/1</trackback:ping></item>
+	</channel></rss>`
+
+	f, err := gofeed.NewParser().ParseString(feed)
+	assert.NoError(t, err)
+
+	assert.Contains(t, f.Items[0].Extensions, "tb")
+	tb, ok := ext.Get[*trackback](f.Items[0].Extensions)
+	if assert.True(t, ok) {
+		assert.Equal(t, "http://example.com/tb/1", tb.Ping)
+	}
+}
+
+func TestGet_BuiltIn(t *testing.T) {
+	feed := `<rss version="2.0" xmlns:itunes="http://www.itunes.com/dtds/podcast-1.0.dtd"><channel>
+		<itunes:author>Feed Author</itunes:author>
+		<item><itunes:duration>1:00</itunes:duration></item>
+	</channel></rss>`
+
+	f, err := gofeed.NewParser().ParseString(feed)
+	assert.NoError(t, err)
+
+	feedExt, ok := ext.Get[*ext.ITunesFeedExtension](f.Extensions)
+	if assert.True(t, ok) {
+		assert.Equal(t, "Feed Author", feedExt.Author)
+	}
+	itemExt, ok := ext.Get[*ext.ITunesItemExtension](f.Items[0].Extensions)
+	if assert.True(t, ok) {
+		assert.Equal(t, "1:00", itemExt.Duration)
+	}
+	assert.Equal(t, f.Items[0].ITunesExt, itemExt)
+}
@@ -0,0 +1,22 @@
+package ext
+
+// FeedHistoryExtension represents a feed extension
+// for Feed Paging and Archiving (fh:).
+// https://www.rfc-editor.org/rfc/rfc5005
+type FeedHistoryExtension struct {
+	// Complete is set by fh:complete, which marks a document holding
+	// every entry of the feed, so no archives need to be fetched.
+	Complete bool `json:"complete,omitempty"`
+	// Archive is set by fh:archive, which marks an archive document
+	// whose entries will not change.
+	Archive bool `json:"archive,omitempty"`
+}
+
+// NewFeedHistoryExtension creates a new FeedHistoryExtension
+// given the generic extension map for the "fh" prefix.
+func NewFeedHistoryExtension(extensions map[string][]Extension) *FeedHistoryExtension {
+	fh := &FeedHistoryExtension{}
+	_, fh.Complete = extensions["complete"]
+	_, fh.Archive = extensions["archive"]
+	return fh
+}
@@ -0,0 +1,366 @@
+package ext
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Query returns every extension element selected by path, a slash
+// separated list of element steps such as
+//
+//	media:group/media:content[@medium='video']
+//
+// The first step selects top level elements by prefix and name; a step
+// without a prefix matches the name under any prefix, and "*" matches any
+// name. Later steps select child elements by local name, since children are
+// stored without their prefix. Each step may carry predicates:
+//
+//	[@attr]           the attribute is present
+//	[@attr='value']   the attribute equals value (!= for not equal)
+//	[text()='value']  the element text equals value
+//	[n]               the n-th match (1-based) under each parent
+//
+// Query returns an error for a malformed path or one that ends at an
+// attribute or text() rather than an element; use QueryValues for those.
+func (e Extensions) Query(path string) ([]Extension, error) {
+	q, err := parseQuery(path)
+	if err != nil {
+		return nil, err
+	}
+	if q.value != "" {
+		return nil, fmt.Errorf("extension query %q selects values, not elements", path)
+	}
+	return q.elements(e), nil
+}
+
+// QueryValues returns the values selected by path: the attribute values
+// when path ends in an @attr step (for example media:content/@url), and
+// the element text otherwise. See Query for the path syntax.
+func (e Extensions) QueryValues(path string) ([]string, error) {
+	q, err := parseQuery(path)
+	if err != nil {
+		return nil, err
+	}
+	return q.values(q.elements(e)), nil
+}
+
+// Query returns the descendants of the extension selected by path, whose
+// first step selects children of e. See Extensions.Query for the syntax.
+func (e Extension) Query(path string) ([]Extension, error) {
+	q, err := parseQuery(path)
+	if err != nil {
+		return nil, err
+	}
+	if q.value != "" {
+		return nil, fmt.Errorf("extension query %q selects values, not elements", path)
+	}
+	return q.descendants([]Extension{e}), nil
+}
+
+// QueryValues returns the values selected by path relative to e. See
+// Extensions.QueryValues.
+func (e Extension) QueryValues(path string) ([]string, error) {
+	q, err := parseQuery(path)
+	if err != nil {
+		return nil, err
+	}
+	return q.values(q.descendants([]Extension{e})), nil
+}
+
+// query is a parsed extension path.
+type query struct {
+	steps []queryStep
+	// value is "@name" or "text()" when the path ends at a value.
+	value string
+}
+
+type queryStep struct {
+	prefix     string
+	name       string
+	predicates []queryPredicate
+}
+
+type queryPredicate struct {
+	// position is the 1-based index for [n] predicates, zero otherwise.
+	position int
+	// attr is the attribute name, or "" for text() comparisons.
+	attr    string
+	compare string // "", "=" or "!="
+	value   string
+}
+
+func parseQuery(path string) (*query, error) {
+	parts, err := splitQuery(path)
+	if err != nil {
+		return nil, err
+	}
+
+	q := &query{}
+	for i, part := range parts {
+		if part == "" {
+			return nil, fmt.Errorf("extension query %q has an empty step", path)
+		}
+		if part == "text()" || strings.HasPrefix(part, "@") {
+			if i != len(parts)-1 {
+				return nil, fmt.Errorf("extension query %q: %s must end the path", path, part)
+			}
+			if part == "text()" {
+				q.value = part
+			} else if name := localName(part); name != "" {
+				q.value = "@" + name
+			} else {
+				return nil, fmt.Errorf("extension query %q has an empty attribute name", path)
+			}
+			break
+		}
+
+		step, err := parseQueryStep(part)
+		if err != nil {
+			return nil, fmt.Errorf("extension query %q: %w", path, err)
+		}
+		q.steps = append(q.steps, step)
+	}
+	return q, nil
+}
+
+// splitQuery splits path on slashes outside of predicates.
+func splitQuery(path string) ([]string, error) {
+	var parts []string
+	depth, quote, start := 0, byte(0), 0
+	for i := 0; i < len(path); i++ {
+		c := path[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case c == '[':
+			depth++
+		case c == ']':
+			depth--
+			if depth < 0 {
+				return nil, fmt.Errorf("extension query %q has an unbalanced ]", path)
+			}
+		case c == '/' && depth == 0:
+			parts = append(parts, strings.TrimSpace(path[start:i]))
+			start = i + 1
+		}
+	}
+	if depth != 0 || quote != 0 {
+		return nil, fmt.Errorf("extension query %q has an unterminated predicate", path)
+	}
+	return append(parts, strings.TrimSpace(path[start:])), nil
+}
+
+func parseQueryStep(part string) (step queryStep, err error) {
+	name := part
+	if i := strings.IndexByte(part, '['); i >= 0 {
+		name = part[:i]
+		rest := part[i:]
+		for rest != "" {
+			end := predicateEnd(rest)
+			if rest[0] != '[' || end < 0 {
+				return step, fmt.Errorf("malformed predicate in %q", part)
+			}
+			pred, err := parseQueryPredicate(strings.TrimSpace(rest[1:end]))
+			if err != nil {
+				return step, err
+			}
+			step.predicates = append(step.predicates, pred)
+			rest = strings.TrimSpace(rest[end+1:])
+		}
+	}
+
+	name = strings.TrimSpace(name)
+	if i := strings.IndexByte(name, ':'); i >= 0 {
+		step.prefix, name = name[:i], name[i+1:]
+	}
+	if name == "" {
+		return step, fmt.Errorf("step %q has no element name", part)
+	}
+	step.name = name
+	return step, nil
+}
+
+// predicateEnd returns the index of the ] closing the predicate that opens
+// s, skipping quoted strings, or -1.
+func predicateEnd(s string) int {
+	quote := byte(0)
+	for i := 1; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case c == ']':
+			return i
+		}
+	}
+	return -1
+}
+
+func parseQueryPredicate(expr string) (pred queryPredicate, err error) {
+	if n, err := strconv.Atoi(expr); err == nil {
+		if n < 1 {
+			return pred, fmt.Errorf("position [%s] must be 1 or more", expr)
+		}
+		pred.position = n
+		return pred, nil
+	}
+
+	// Only look for the operator before any quoted value.
+	lhs, head := expr, expr
+	if i := strings.IndexAny(expr, `'"`); i >= 0 {
+		head = expr[:i]
+	}
+	if i := strings.Index(head, "!="); i >= 0 {
+		lhs, pred.compare, pred.value = expr[:i], "!=", expr[i+2:]
+	} else if i := strings.IndexByte(head, '='); i >= 0 {
+		lhs, pred.compare, pred.value = expr[:i], "=", expr[i+1:]
+	}
+	lhs = strings.TrimSpace(lhs)
+
+	if pred.compare != "" {
+		v := strings.TrimSpace(pred.value)
+		if len(v) < 2 || (v[0] != '\'' && v[0] != '"') || v[len(v)-1] != v[0] {
+			return pred, fmt.Errorf("predicate [%s] must compare with a quoted string", expr)
+		}
+		pred.value = v[1 : len(v)-1]
+	}
+
+	switch {
+	case strings.HasPrefix(lhs, "@") && localName(lhs) != "":
+		pred.attr = localName(lhs)
+	case lhs == "text()" && pred.compare != "":
+	default:
+		return pred, fmt.Errorf("unsupported predicate [%s]", expr)
+	}
+	return pred, nil
+}
+
+// localName strips a leading @ and any prefix from an attribute name;
+// Attrs is keyed by local name.
+func localName(name string) string {
+	name = strings.TrimPrefix(name, "@")
+	if i := strings.IndexByte(name, ':'); i >= 0 {
+		name = name[i+1:]
+	}
+	return name
+}
+
+func (q *query) elements(exts Extensions) []Extension {
+	if len(q.steps) == 0 {
+		return nil
+	}
+	first := q.steps[0]
+
+	var matches []Extension
+	for _, prefix := range sortedKeys(exts) {
+		if first.prefix != "" && first.prefix != "*" && first.prefix != prefix {
+			continue
+		}
+		matches = append(matches, first.filter(exts[prefix])...)
+	}
+	return q.walk(matches, q.steps[1:])
+}
+
+func (q *query) descendants(roots []Extension) []Extension {
+	return q.walk(roots, q.steps)
+}
+
+func (q *query) walk(current []Extension, steps []queryStep) []Extension {
+	for _, step := range steps {
+		var next []Extension
+		for _, parent := range current {
+			next = append(next, step.filter(parent.Children)...)
+		}
+		current = next
+	}
+	return current
+}
+
+func (q *query) values(elements []Extension) (values []string) {
+	for _, e := range elements {
+		switch {
+		case strings.HasPrefix(q.value, "@"):
+			if v, ok := e.Attrs[q.value[1:]]; ok {
+				values = append(values, v)
+			}
+		default:
+			values = append(values, e.Value)
+		}
+	}
+	return values
+}
+
+// filter returns the elements of byName matched by the step's name and
+// predicates, in name and then document order.
+func (s queryStep) filter(byName map[string][]Extension) []Extension {
+	var names []string
+	if s.name == "*" {
+		names = sortedKeys(byName)
+	} else {
+		names = []string{s.name}
+	}
+
+	var matches []Extension
+	for _, name := range names {
+		matches = append(matches, byName[name]...)
+	}
+	for _, pred := range s.predicates {
+		matches = pred.filter(matches)
+	}
+	return matches
+}
+
+func (p queryPredicate) filter(elements []Extension) []Extension {
+	if p.position > 0 {
+		if p.position > len(elements) {
+			return nil
+		}
+		return elements[p.position-1 : p.position]
+	}
+
+	var matches []Extension
+	for _, e := range elements {
+		var actual string
+		var present bool
+		if p.attr != "" {
+			actual, present = e.Attrs[p.attr]
+		} else {
+			actual, present = e.Value, true
+		}
+
+		switch p.compare {
+		case "":
+			if present {
+				matches = append(matches, e)
+			}
+		case "=":
+			if present && actual == p.value {
+				matches = append(matches, e)
+			}
+		case "!=":
+			if !present || actual != p.value {
+				matches = append(matches, e)
+			}
+		}
+	}
+	return matches
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,73 @@
+package ext_test
+
+import (
+	"testing"
+
+	"github.com/mmcdole/gofeed"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtensions_Query(t *testing.T) {
+	feed := `<rss version="2.0" xmlns:media="http://search.yahoo.com/mrss/"><channel><item>
+		<media:group>
+			<media:content url="http://example.com/a.mp4" medium="video"/>
+			<media:content url="http://example.com/a.mp3" medium="audio"/>
+			<media:content url="http://example.com/b.mp4" medium="video"/>
+			<media:title>Group title</media:title>
+		</media:group>
+		<media:thumbnail url="http://example.com/t.jpg"/>
+	</item></channel></rss>`
+
+	f, err := gofeed.NewParser().ParseString(feed)
+	assert.NoError(t, err)
+	exts := f.Items[0].Extensions
+
+	tests := []struct {
+		path string
+		want []string
+	}{
+		{"media:group/media:content[@medium='video']/@url", []string{"http://example.com/a.mp4", "http://example.com/b.mp4"}},
+		{`media:group/content[@medium!="video"]/@url`, []string{"http://example.com/a.mp3"}},
+		{"media:group/media:content[2]/@url", []string{"http://example.com/a.mp3"}},
+		{"media:group/media:content[@medium='video'][2]/@url", []string{"http://example.com/b.mp4"}},
+		{"media:group/media:title", []string{"Group title"}},
+		{"media:group/title[text()='Group title']/text()", []string{"Group title"}},
+		{"thumbnail/@url", []string{"http://example.com/t.jpg"}},
+		{"media:*/@url", []string{"http://example.com/t.jpg"}},
+		{"media:group/content[@lang]/@url", nil},
+		{"itunes:author", nil},
+	}
+	for _, test := range tests {
+		got, err := exts.QueryValues(test.path)
+		assert.NoError(t, err, test.path)
+		assert.Equal(t, test.want, got, test.path)
+	}
+
+	groups, err := exts.Query("media:group")
+	assert.NoError(t, err)
+	if assert.Len(t, groups, 1) {
+		urls, err := groups[0].QueryValues("content[@medium='audio']/@url")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"http://example.com/a.mp3"}, urls)
+
+		contents, err := groups[0].Query("content")
+		assert.NoError(t, err)
+		assert.Len(t, contents, 3)
+	}
+
+	for _, path := range []string{
+		"",
+		"media:group//content",
+		"media:group/content[@medium='video'",
+		"media:group/content[0]",
+		"media:group/content[@medium=video]",
+		"media:group/@url/content",
+		"media:group/content[last()]",
+	} {
+		_, err := exts.QueryValues(path)
+		assert.Error(t, err, path)
+	}
+
+	_, err = exts.Query("media:thumbnail/@url")
+	assert.Error(t, err)
+}
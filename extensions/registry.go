@@ -0,0 +1,98 @@
+package ext
+
+import (
+	"reflect"
+	"sync"
+)
+
+// registration is a decoder registered for one namespace and result type.
+type registration struct {
+	namespace string
+	prefix    string
+	typ       reflect.Type
+	decode    func(map[string][]Extension) interface{}
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   []registration
+)
+
+// Register registers decode as the way to turn the elements of the
+// namespace URI ns into a typed T. Elements in ns are stored in Extensions
+// under prefix, whatever prefix a feed itself declares for ns and even for
+// the well-known namespaces gofeed otherwise stores under a canonical
+// prefix, and Get[T] decodes them on demand.
+//
+// A namespace may be registered more than once with different result types
+// (for example a feed-level and an item-level struct), and several
+// namespaces may decode to the same type; Get returns the first registered
+// match present. Register is safe to call concurrently with parsing, but
+// is normally called from an init function.
+func Register[T any](ns, prefix string, decode func(extensions map[string][]Extension) T) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry = append(registry, registration{
+		namespace: ns,
+		prefix:    prefix,
+		typ:       reflect.TypeFor[T](),
+		decode: func(extensions map[string][]Extension) interface{} {
+			return decode(extensions)
+		},
+	})
+}
+
+// Get decodes the extension registered for type T from exts, for example
+// ext.Get[*ext.ITunesItemExtension](item.Extensions). ok is false when no
+// namespace registered for T has any elements in exts.
+func Get[T any](exts Extensions) (value T, ok bool) {
+	typ := reflect.TypeFor[T]()
+
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	for _, r := range registry {
+		if r.typ != typ {
+			continue
+		}
+		if elements, found := exts[r.prefix]; found {
+			return r.decode(elements).(T), true
+		}
+	}
+	return value, false
+}
+
+// RegisteredPrefix returns the prefix a namespace URI was registered with.
+func RegisteredPrefix(ns string) (prefix string, ok bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	for _, r := range registry {
+		if r.namespace == ns {
+			return r.prefix, true
+		}
+	}
+	return "", false
+}
+
+// The built-in typed extensions are registered the same way callers
+// register their own.
+func init() {
+	for _, ns := range []string{
+		"http://www.itunes.com/DTDs/PodCast-1.0.dtd",
+		"http://www.itunes.com/dtds/podcast-1.0.dtd",
+	} {
+		Register(ns, "itunes", NewITunesFeedExtension)
+		Register(ns, "itunes", NewITunesItemExtension)
+	}
+	Register("http://purl.org/dc/elements/1.1/", "dc", NewDublinCoreExtension)
+	Register("http://purl.org/dc/terms/", "dcterms", NewDCTermsExtension)
+	Register("http://purl.org/rss/1.0/modules/syndication/", "sy", NewSyndicationExtension)
+	Register("http://purl.org/syndication/history/1.0", "fh", NewFeedHistoryExtension)
+	Register("http://wellformedweb.org/commentAPI/", "wfw", NewWellFormedWebExtension)
+	Register("http://purl.org/rss/1.0/modules/slash/", "slash", NewSlashExtension)
+	Register("http://purl.org/syndication/thread/1.0", "thr", NewThreadingExtension)
+	Register("http://purl.org/rss/1.0/modules/threading/", "thr", NewThreadingExtension)
+	Register("http://backend.userland.com/creativeCommonsRssModule", "creativeCommons", NewCreativeCommonsExtension)
+	Register("http://cyber.law.harvard.edu/rss/creativeCommonsRssModule.html", "creativeCommons", NewCreativeCommonsExtension)
+	Register("http://web.resource.org/cc/", "cc", NewCreativeCommonsExtension)
+	Register("http://creativecommons.org/ns#", "cc", NewCreativeCommonsExtension)
+}
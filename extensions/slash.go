@@ -0,0 +1,22 @@
+package ext
+
+// SlashExtension represents a feed extension
+// for the RSS 1.0 Slash module (slash:).
+// http://web.resource.org/rss/1.0/modules/slash/
+type SlashExtension struct {
+	Section    string `json:"section,omitempty"`
+	Department string `json:"department,omitempty"`
+	Comments   string `json:"comments,omitempty"`
+	HitParade  string `json:"hitParade,omitempty"`
+}
+
+// NewSlashExtension creates a new SlashExtension
+// given the generic extension map for the "slash" prefix.
+func NewSlashExtension(extensions map[string][]Extension) *SlashExtension {
+	slash := &SlashExtension{}
+	slash.Section = parseTextExtension("section", extensions)
+	slash.Department = parseTextExtension("department", extensions)
+	slash.Comments = parseTextExtension("comments", extensions)
+	slash.HitParade = parseTextExtension("hit_parade", extensions)
+	return slash
+}
@@ -0,0 +1,20 @@
+package ext
+
+// SyndicationExtension represents a feed extension
+// for the RSS 1.0 Syndication module (sy:).
+// http://web.resource.org/rss/1.0/modules/syndication/
+type SyndicationExtension struct {
+	UpdatePeriod    string `json:"updatePeriod,omitempty"`
+	UpdateFrequency string `json:"updateFrequency,omitempty"`
+	UpdateBase      string `json:"updateBase,omitempty"`
+}
+
+// NewSyndicationExtension creates a new SyndicationExtension
+// given the generic extension map for the "sy" prefix.
+func NewSyndicationExtension(extensions map[string][]Extension) *SyndicationExtension {
+	sy := &SyndicationExtension{}
+	sy.UpdatePeriod = parseTextExtension("updatePeriod", extensions)
+	sy.UpdateFrequency = parseTextExtension("updateFrequency", extensions)
+	sy.UpdateBase = parseTextExtension("updateBase", extensions)
+	return sy
+}
@@ -0,0 +1,49 @@
+package ext
+
+// ThreadingExtension represents a feed extension
+// for Atom Threading (thr:), RFC 4685.
+// https://www.rfc-editor.org/rfc/rfc4685
+type ThreadingExtension struct {
+	Total     string                `json:"total,omitempty"`
+	InReplyTo []*ThreadingInReplyTo `json:"inReplyTo,omitempty"`
+}
+
+// ThreadingInReplyTo identifies the resource an entry
+// is a response to.
+type ThreadingInReplyTo struct {
+	Ref    string `json:"ref,omitempty"`
+	Href   string `json:"href,omitempty"`
+	Type   string `json:"type,omitempty"`
+	Source string `json:"source,omitempty"`
+}
+
+// NewThreadingExtension creates a new ThreadingExtension
+// given the generic extension map for the "thr" prefix.
+func NewThreadingExtension(extensions map[string][]Extension) *ThreadingExtension {
+	thr := &ThreadingExtension{}
+	thr.Total = parseTextExtension("total", extensions)
+	thr.InReplyTo = parseInReplyTo(extensions)
+	return thr
+}
+
+func parseInReplyTo(extensions map[string][]Extension) (replies []*ThreadingInReplyTo) {
+	if extensions == nil {
+		return
+	}
+
+	matches, ok := extensions["in-reply-to"]
+	if !ok || len(matches) == 0 {
+		return
+	}
+
+	replies = []*ThreadingInReplyTo{}
+	for _, m := range matches {
+		replies = append(replies, &ThreadingInReplyTo{
+			Ref:    m.Attrs["ref"],
+			Href:   m.Attrs["href"],
+			Type:   m.Attrs["type"],
+			Source: m.Attrs["source"],
+		})
+	}
+	return
+}
@@ -0,0 +1,22 @@
+package ext
+
+// WellFormedWebExtension represents a feed extension
+// for the Well-Formed Web Comment API (wfw:).
+// http://wellformedweb.org/news/wfw_namespace_elements/
+type WellFormedWebExtension struct {
+	Comment    string `json:"comment,omitempty"`
+	CommentRSS string `json:"commentRss,omitempty"`
+}
+
+// NewWellFormedWebExtension creates a new WellFormedWebExtension
+// given the generic extension map for the "wfw" prefix.
+func NewWellFormedWebExtension(extensions map[string][]Extension) *WellFormedWebExtension {
+	wfw := &WellFormedWebExtension{}
+	wfw.Comment = parseTextExtension("comment", extensions)
+	wfw.CommentRSS = parseTextExtension("commentRss", extensions)
+	if wfw.CommentRSS == "" {
+		// The original spec spelled it commentRSS; both are in the wild.
+		wfw.CommentRSS = parseTextExtension("commentRSS", extensions)
+	}
+	return wfw
+}
@@ -12,30 +12,51 @@ import (
 // a web feed.
 // Sorting with sort.Sort will order the Items by
 // oldest to newest publish time.
+//
+// Updated and Published hold dates as written in the feed, and
+// UpdatedParsed and PublishedParsed the same dates parsed, or nil when
+// they are not dates. Parser normalizes every parsed time of a Feed and
+// its Items, including UpdateSchedule.Base, to UTC, unless
+// Parser.PreserveTimeZone is set: then each keeps the offset its date is
+// written with, and a date written without one is in UTC. A Feed
+// translated without a Parser keeps whatever offsets its translator gives,
+// as with DefaultJSONTranslator, which keeps the offsets JSON Feed dates
+// are written with.
 type Feed struct {
-	Title           string                   `json:"title,omitempty"`
-	Description     string                   `json:"description,omitempty"`
-	Link            string                   `json:"link,omitempty"`
-	FeedLink        string                   `json:"feedLink,omitempty"`
-	Links           []string                 `json:"links,omitempty"`
-	Updated         string                   `json:"updated,omitempty"`
-	UpdatedParsed   *time.Time               `json:"updatedParsed,omitempty"`
-	Published       string                   `json:"published,omitempty"`
-	PublishedParsed *time.Time               `json:"publishedParsed,omitempty"`
-	Author          *Person                  `json:"author,omitempty"` // Deprecated: Use feed.Authors instead
-	Authors         []*Person                `json:"authors,omitempty"`
-	Language        string                   `json:"language,omitempty"`
-	Image           *Image                   `json:"image,omitempty"`
-	Copyright       string                   `json:"copyright,omitempty"`
-	Generator       string                   `json:"generator,omitempty"`
-	Categories      []string                 `json:"categories,omitempty"`
-	DublinCoreExt   *ext.DublinCoreExtension `json:"dcExt,omitempty"`
-	ITunesExt       *ext.ITunesFeedExtension `json:"itunesExt,omitempty"`
-	Extensions      ext.Extensions           `json:"extensions,omitempty"`
-	Custom          map[string]string        `json:"custom,omitempty"`
-	Items           []*Item                  `json:"items"`
-	FeedType        string                   `json:"feedType"`
-	FeedVersion     string                   `json:"feedVersion"`
+	Title           string                    `json:"title,omitempty"`
+	Description     string                    `json:"description,omitempty"`
+	UserComment     string                    `json:"userComment,omitempty"`
+	Link            string                    `json:"link,omitempty"`
+	FeedLink        string                    `json:"feedLink,omitempty"`
+	NextURL         string                    `json:"nextUrl,omitempty"`
+	Hubs            []string                  `json:"hubs,omitempty"`
+	Links           []string                  `json:"links,omitempty"`
+	Updated         string                    `json:"updated,omitempty"`
+	UpdatedParsed   *time.Time                `json:"updatedParsed,omitempty"` // Updated parsed, in UTC unless Parser.PreserveTimeZone is set
+	Published       string                    `json:"published,omitempty"`
+	PublishedParsed *time.Time                `json:"publishedParsed,omitempty"` // Published parsed, in UTC unless Parser.PreserveTimeZone is set
+	Author          *Person                   `json:"author,omitempty"`          // Deprecated: Use feed.Authors instead
+	Authors         []*Person                 `json:"authors,omitempty"`
+	Language        string                    `json:"language,omitempty"`
+	Image           *Image                    `json:"image,omitempty"`
+	Favicon         *Image                    `json:"favicon,omitempty"`
+	Copyright       string                    `json:"copyright,omitempty"`
+	License         *License                  `json:"license,omitempty"`
+	Generator       string                    `json:"generator,omitempty"`
+	Categories      []string                  `json:"categories,omitempty"`
+	Expired         bool                      `json:"expired,omitempty"`
+	DeletedItemIDs  []string                  `json:"deletedItemIds,omitempty"`
+	DublinCoreExt   *ext.DublinCoreExtension  `json:"dcExt,omitempty"`
+	DCTermsExt      *ext.DCTermsExtension     `json:"dctermsExt,omitempty"`
+	ITunesExt       *ext.ITunesFeedExtension  `json:"itunesExt,omitempty"`
+	SyndicationExt  *ext.SyndicationExtension `json:"syExt,omitempty"`
+	UpdateSchedule  *UpdateSchedule           `json:"updateSchedule,omitempty"`
+	History         *FeedHistory              `json:"history,omitempty"`
+	Extensions      ext.Extensions            `json:"extensions,omitempty"`
+	Custom          map[string]string         `json:"custom,omitempty"`
+	Items           []*Item                   `json:"items"`
+	FeedType        string                    `json:"feedType"`
+	FeedVersion     string                    `json:"feedVersion"`
 
 	// originalFeed holds the source *rss.Feed, *atom.Feed, or *json.Feed when
 	// the parser was configured with KeepOriginalFeed. It is unexported (and so
@@ -49,11 +70,12 @@ func (f Feed) String() string {
 	return string(json)
 }
 
-// OriginalFeed returns the source feed object (*rss.Feed, *atom.Feed, or
-// *json.Feed) this Feed was translated from, giving access to format-specific
-// fields not present on the universal Feed. It is only populated when the
-// parser has KeepOriginalFeed set, and returns nil otherwise. The original is
-// held in memory, not serialized, so it does not survive a Feed that has been
+// OriginalFeed returns the source feed object (*rss.Feed, *atom.Feed,
+// *json.Feed, *hfeed.Feed or *activitystreams.Collection) this Feed was
+// translated from, giving access to format-specific fields not present on
+// the universal Feed. It is only populated when the parser has
+// KeepOriginalFeed set, and returns nil otherwise. The original is held in
+// memory, not serialized, so it does not survive a Feed that has been
 // marshaled and unmarshaled.
 func (f Feed) OriginalFeed() interface{} {
 	return f.originalFeed
@@ -68,17 +90,23 @@ type Item struct {
 	Content         string                   `json:"content,omitempty"`
 	Link            string                   `json:"link,omitempty"`
 	Links           []string                 `json:"links,omitempty"`
+	ExternalURL     string                   `json:"externalUrl,omitempty"`
 	Updated         string                   `json:"updated,omitempty"`
-	UpdatedParsed   *time.Time               `json:"updatedParsed,omitempty"`
+	UpdatedParsed   *time.Time               `json:"updatedParsed,omitempty"` // Updated parsed, in UTC unless Parser.PreserveTimeZone is set
 	Published       string                   `json:"published,omitempty"`
-	PublishedParsed *time.Time               `json:"publishedParsed,omitempty"`
-	Author          *Person                  `json:"author,omitempty"` // Deprecated: Use item.Authors instead
+	PublishedParsed *time.Time               `json:"publishedParsed,omitempty"` // Published parsed, in UTC unless Parser.PreserveTimeZone is set
+	Author          *Person                  `json:"author,omitempty"`          // Deprecated: Use item.Authors instead
 	Authors         []*Person                `json:"authors,omitempty"`
 	GUID            string                   `json:"guid,omitempty"`
 	Image           *Image                   `json:"image,omitempty"`
+	BannerImage     *Image                   `json:"bannerImage,omitempty"`
 	Categories      []string                 `json:"categories,omitempty"`
 	Enclosures      []*Enclosure             `json:"enclosures,omitempty"`
+	License         *License                 `json:"license,omitempty"`
+	Comments        *Comments                `json:"comments,omitempty"`
+	InReplyTo       []*InReplyTo             `json:"inReplyTo,omitempty"`
 	DublinCoreExt   *ext.DublinCoreExtension `json:"dcExt,omitempty"`
+	DCTermsExt      *ext.DCTermsExtension    `json:"dctermsExt,omitempty"`
 	ITunesExt       *ext.ITunesItemExtension `json:"itunesExt,omitempty"`
 	Extensions      ext.Extensions           `json:"extensions,omitempty"`
 	Custom          map[string]string        `json:"custom,omitempty"`
@@ -87,8 +115,10 @@ type Item struct {
 // Person is an individual specified in a feed
 // (e.g. an author)
 type Person struct {
-	Name  string `json:"name,omitempty"`
-	Email string `json:"email,omitempty"`
+	Name   string `json:"name,omitempty"`
+	Email  string `json:"email,omitempty"`
+	URL    string `json:"url,omitempty"`
+	Avatar string `json:"avatar,omitempty"`
 }
 
 // Image is an image that is the artwork for a given
@@ -103,6 +133,28 @@ type Enclosure struct {
 	URL    string `json:"url,omitempty"`
 	Length string `json:"length,omitempty"`
 	Type   string `json:"type,omitempty"`
+	Title  string `json:"title,omitempty"`
+	// Duration is how long the media plays, or zero when unknown.
+	Duration time.Duration `json:"duration,omitempty"`
+}
+
+// Comments describes the discussion attached to an Item:
+// where to read it, where to follow it as a feed, and
+// how many comments there are.
+type Comments struct {
+	URL     string `json:"url,omitempty"`
+	FeedURL string `json:"feedUrl,omitempty"`
+	// Count is nil when the feed does not give a comment count.
+	Count *int `json:"count,omitempty"`
+}
+
+// InReplyTo identifies the resource an Item is a
+// response to (RFC 4685 thr:in-reply-to).
+type InReplyTo struct {
+	Ref    string `json:"ref,omitempty"`
+	Href   string `json:"href,omitempty"`
+	Type   string `json:"type,omitempty"`
+	Source string `json:"source,omitempty"`
 }
 
 // Len returns the length of Items.
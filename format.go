@@ -0,0 +1,272 @@
+package gofeed
+
+import (
+	"errors"
+	"io"
+	"mime"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/mmcdole/gofeed/hfeed"
+	"github.com/mmcdole/gofeed/json"
+)
+
+// Format is a document format the universal Parser can detect, parse and
+// translate to the universal Feed. The built-in formats are registered
+// with RegisterFormat like any other, under the names "rss", "atom",
+// "json", "hfeed" and "activitystreams"; LookupFormat returns them for
+// copying or wrapping.
+type Format struct {
+	// Name identifies the format in the registry.
+	Name string
+	// Type is the FeedType DetectFeedType reports for the format. It is
+	// assigned by RegisterFormat.
+	Type FeedType
+	// Priority orders the formats' detectors: higher priorities are
+	// consulted first, and formats of equal priority in the order they were
+	// registered. The built-in XML and ActivityStreams formats use 20,
+	// h-feed 10 and JSON Feed 0.
+	Priority int
+	// Detect reports whether a document is in the format from its first
+	// bytes: the first few KB, which is all Parser.Parse, DetectFeedType
+	// and Detect read to tell the format. A document whose telltale
+	// markers all come later is not detected by its content alone.
+	Detect func(prefix []byte) bool
+	// MediaTypes are the media types servers label the format with, such
+	// as "application/rss+xml". When a response's Content-Type names one
+	// of them, the format is preferred over others accepting the document,
+	// and tried on a document no detector accepts but that does not look
+	// like something else, such as an HTML page or an error.
+	MediaTypes []string
+	// Labeled optionally narrows which of those documents, rejected by
+	// Detect but labeled with one of MediaTypes, are tried in the format.
+	// Nil tries them all.
+	Labeled func(prefix []byte) bool
+	// Version optionally returns the format version of a document Detect
+	// accepted, for Detect.
+	Version func(prefix []byte) string
+	// Parse parses a document into the format's own feed type. f is the
+	// Parser parsing it, whose options, such as PreserveTimeZone, the
+	// built-in formats pass on to their parsers.
+	Parse func(f *Parser, feed io.Reader) (interface{}, error)
+	// Translator translates what Parse returns to the universal Feed. The
+	// per-format translator fields of a Parser, such as RSSTranslator,
+	// take precedence for the format registered under the matching name.
+	Translator Translator
+}
+
+var (
+	formatsMu sync.RWMutex
+	formats   []*Format
+	// nextFeedType is the FeedType the next newly registered format gets.
+	// The built-in formats are registered first, in the order of their
+	// FeedType constants, and so get them.
+	nextFeedType = FeedTypeAtom
+)
+
+// RegisterFormat adds a format to the registry the universal Parser and
+// DetectFeedType consult, and returns its FeedType. Name, Detect, Parse and
+// Translator must all be set; RegisterFormat panics when one is missing. A
+// format registered under the name of an existing one, built-in formats
+// included, replaces it and keeps its FeedType.
+//
+// RegisterFormat is meant to be called from init functions; it is safe to
+// call concurrently with parsing, which sees either the old or the new
+// registry.
+func RegisterFormat(format Format) FeedType {
+	switch {
+	case format.Name == "":
+		panic("gofeed: RegisterFormat format has no Name")
+	case format.Detect == nil:
+		panic("gofeed: RegisterFormat format " + format.Name + " has no Detect")
+	case format.Parse == nil:
+		panic("gofeed: RegisterFormat format " + format.Name + " has no Parse")
+	case format.Translator == nil:
+		panic("gofeed: RegisterFormat format " + format.Name + " has no Translator")
+	}
+
+	formatsMu.Lock()
+	defer formatsMu.Unlock()
+
+	registered := make([]*Format, 0, len(formats)+1)
+	format.Type = FeedTypeUnknown
+	for _, existing := range formats {
+		if existing.Name == format.Name {
+			format.Type = existing.Type
+			continue
+		}
+		registered = append(registered, existing)
+	}
+	if format.Type == FeedTypeUnknown {
+		format.Type = nextFeedType
+		nextFeedType++
+	}
+	// Replacing a format moves it behind the others of its priority.
+	formats = sortFormats(append(registered, &format))
+	return format.Type
+}
+
+// detectFormat returns the first registered format whose detector accepts
+// prefix, or nil.
+func detectFormat(prefix []byte) *Format {
+	formatsMu.RLock()
+	registered := formats
+	formatsMu.RUnlock()
+
+	for _, format := range registered {
+		if format.Detect(prefix) {
+			return format
+		}
+	}
+	return nil
+}
+
+// labeledFormat returns the format whose MediaTypes include the media type
+// of contentType, a Content-Type header value, or nil.
+func labeledFormat(contentType string) *Format {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil
+	}
+
+	formatsMu.RLock()
+	defer formatsMu.RUnlock()
+	for _, format := range formats {
+		for _, t := range format.MediaTypes {
+			if strings.EqualFold(t, mediaType) {
+				return format
+			}
+		}
+	}
+	return nil
+}
+
+// lookupFormat returns the format registered under name, or nil.
+func lookupFormat(name string) *Format {
+	formatsMu.RLock()
+	defer formatsMu.RUnlock()
+	for _, format := range formats {
+		if format.Name == name {
+			return format
+		}
+	}
+	return nil
+}
+
+// LookupFormat returns the format registered under name.
+func LookupFormat(name string) (Format, bool) {
+	if format := lookupFormat(name); format != nil {
+		return *format, true
+	}
+	return Format{}, false
+}
+
+// sortFormats orders formats by priority, keeping the registration order
+// of equal priorities.
+func sortFormats(formats []*Format) []*Format {
+	sort.SliceStable(formats, func(i, j int) bool {
+		return formats[i].Priority > formats[j].Priority
+	})
+	return formats
+}
+
+func init() {
+	RegisterFormat(Format{
+		Name:       "atom",
+		Priority:   20,
+		Detect:     detectAtom,
+		MediaTypes: []string{"application/atom+xml"},
+		Labeled:    labeledXML,
+		Version:    atomVersion,
+		Parse: func(f *Parser, feed io.Reader) (interface{}, error) {
+			return f.atomParser().Parse(feed)
+		},
+		Translator: defaultAtomTranslator,
+	})
+	RegisterFormat(Format{
+		Name:       "rss",
+		Priority:   20,
+		Detect:     detectRSS,
+		MediaTypes: []string{"application/rss+xml", "application/rdf+xml"},
+		Labeled:    labeledXML,
+		Version:    rssVersion,
+		Parse: func(f *Parser, feed io.Reader) (interface{}, error) {
+			return f.rssParser().Parse(feed)
+		},
+		Translator: defaultRSSTranslator,
+	})
+	RegisterFormat(Format{
+		Name:       "json",
+		Priority:   0,
+		Detect:     detectJSON,
+		MediaTypes: []string{"application/feed+json", "application/json"},
+		Labeled:    labeledJSON,
+		Version:    jsonFeedVersion,
+		Parse: func(f *Parser, feed io.Reader) (interface{}, error) {
+			return (&json.Parser{}).Parse(feed)
+		},
+		Translator: defaultJSONTranslator,
+	})
+	RegisterFormat(Format{
+		Name:       "hfeed",
+		Priority:   10,
+		Detect:     detectHFeed,
+		MediaTypes: []string{"text/html", "application/xhtml+xml"},
+		Parse: func(f *Parser, feed io.Reader) (interface{}, error) {
+			return f.hfeedParser().Parse(feed)
+		},
+		Translator: defaultHFeedTranslator,
+	})
+	RegisterFormat(Format{
+		Name:       "activitystreams",
+		Priority:   20,
+		Detect:     detectActivityStreams,
+		MediaTypes: []string{"application/activity+json", "application/ld+json"},
+		Labeled:    labeledActivityStreams,
+		Version:    func(prefix []byte) string { return "2.0" },
+		Parse: func(f *Parser, feed io.Reader) (interface{}, error) {
+			return f.activityStreamsParser().Parse(feed)
+		},
+		Translator: defaultActivityStreamsTranslator,
+	})
+}
+
+// translator returns the Parser's own translator for the format registered
+// under name, or nil.
+func (f *Parser) translator(name string) Translator {
+	switch name {
+	case "rss":
+		return f.RSSTranslator
+	case "atom":
+		return f.AtomTranslator
+	case "json":
+		return f.JSONTranslator
+	case "hfeed":
+		return f.HFeedTranslator
+	case "activitystreams":
+		return f.ActivityStreamsTranslator
+	}
+	return nil
+}
+
+// parseFormat parses feed in format and translates the result.
+func (f *Parser) parseFormat(format *Format, feed io.Reader) (*Feed, error) {
+	original, err := format.Parse(f, feed)
+	if errors.Is(err, hfeed.ErrNoFeed) {
+		// The page looked like an h-feed, but its classes came to nothing.
+		return nil, ErrHTMLPage
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	translator := format.Translator
+	if t := f.translator(format.Name); t != nil {
+		translator = t
+	}
+	result, err := translator.Translate(original)
+	f.normalizeTimes(result)
+	f.keepOriginal(result, original)
+	return result, err
+}
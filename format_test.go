@@ -0,0 +1,184 @@
+package gofeed_test
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/mmcdole/gofeed"
+	"github.com/stretchr/testify/assert"
+)
+
+// twtxt is a minimal custom format: a "# twtxt" header line followed by
+// one "<timestamp>\t<text>" line per item.
+type twtxt struct {
+	lines [][2]string
+}
+
+type twtxtTranslator struct{}
+
+func (twtxtTranslator) Translate(feed interface{}) (*gofeed.Feed, error) {
+	tw, ok := feed.(*twtxt)
+	if !ok {
+		return nil, fmt.Errorf("Feed did not match expected type of *twtxt")
+	}
+	result := &gofeed.Feed{FeedType: "twtxt"}
+	for _, line := range tw.lines {
+		result.Items = append(result.Items, &gofeed.Item{Published: line[0], Content: line[1]})
+	}
+	return result, nil
+}
+
+func parseTwtxt(f *gofeed.Parser, r io.Reader) (interface{}, error) {
+	tw := &twtxt{}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		if timestamp, text, ok := strings.Cut(scanner.Text(), "\t"); ok {
+			tw.lines = append(tw.lines, [2]string{timestamp, text})
+		}
+	}
+	return tw, scanner.Err()
+}
+
+func TestRegisterFormat(t *testing.T) {
+	feedType := gofeed.RegisterFormat(gofeed.Format{
+		Name: "test-twtxt",
+		Detect: func(prefix []byte) bool {
+			return bytes.HasPrefix(prefix, []byte("# twtxt\n"))
+		},
+		Parse:      parseTwtxt,
+		Translator: twtxtTranslator{},
+	})
+	assert.Greater(t, int(feedType), int(gofeed.FeedTypeActivityStreams))
+
+	doc := "# twtxt\n2024-01-01T00:00:00Z\tHello\n2024-01-02T00:00:00Z\tWorld\n"
+	assert.Equal(t, feedType, gofeed.DetectFeedType(strings.NewReader(doc)))
+
+	feed, err := gofeed.NewParser().ParseString(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "twtxt", feed.FeedType)
+	assert.Len(t, feed.Items, 2)
+	assert.Equal(t, "World", feed.Items[1].Content)
+
+	// Registering the name again replaces the format and keeps its type.
+	again := gofeed.RegisterFormat(gofeed.Format{
+		Name:       "test-twtxt",
+		Detect:     func(prefix []byte) bool { return false },
+		Parse:      parseTwtxt,
+		Translator: twtxtTranslator{},
+	})
+	assert.Equal(t, feedType, again)
+	assert.Equal(t, gofeed.FeedTypeUnknown, gofeed.DetectFeedType(strings.NewReader(doc)))
+}
+
+// A format with a positive priority is consulted before JSON Feed.
+func TestRegisterFormat_Priority(t *testing.T) {
+	isError := func(prefix []byte) bool {
+		return bytes.Contains(prefix, []byte(`"test-error"`))
+	}
+	doc := `{"version": "https://jsonfeed.org/version/1.1", "test-error": "rate limited", "items": []}`
+
+	errorFormat := func(name string, priority int) gofeed.Format {
+		return gofeed.Format{Name: name, Priority: priority, Detect: isError, Parse: parseTwtxt, Translator: twtxtTranslator{}}
+	}
+
+	low := gofeed.RegisterFormat(errorFormat("test-error-low", -1))
+	assert.Equal(t, gofeed.FeedTypeJSON, gofeed.DetectFeedType(strings.NewReader(doc)))
+
+	high := gofeed.RegisterFormat(errorFormat("test-error-high", 1))
+	assert.NotEqual(t, low, high)
+	assert.Equal(t, high, gofeed.DetectFeedType(strings.NewReader(doc)))
+
+	// Leave JSON detection to JSON Feed for the other tests.
+	noMatch := errorFormat("test-error-high", 1)
+	noMatch.Detect = func(prefix []byte) bool { return false }
+	gofeed.RegisterFormat(noMatch)
+}
+
+// A format missing what parsing it takes is refused when it is registered,
+// rather than failing when a document is parsed.
+func TestRegisterFormat_Incomplete(t *testing.T) {
+	complete := gofeed.Format{
+		Name:       "test-incomplete",
+		Detect:     func(prefix []byte) bool { return false },
+		Parse:      parseTwtxt,
+		Translator: twtxtTranslator{},
+	}
+	for field, clear := range map[string]func(*gofeed.Format){
+		"Name":       func(f *gofeed.Format) { f.Name = "" },
+		"Detect":     func(f *gofeed.Format) { f.Detect = nil },
+		"Parse":      func(f *gofeed.Format) { f.Parse = nil },
+		"Translator": func(f *gofeed.Format) { f.Translator = nil },
+	} {
+		format := complete
+		clear(&format)
+		func() {
+			defer func() {
+				assert.Contains(t, fmt.Sprint(recover()), "has no "+field)
+			}()
+			gofeed.RegisterFormat(format)
+		}()
+	}
+}
+
+// A built-in format copied under a new name keeps honoring the Parser's
+// options and the media types it was labeled with.
+func TestRegisterFormat_WrappedBuiltin(t *testing.T) {
+	rssFormat, ok := gofeed.LookupFormat("rss")
+	if !ok {
+		t.Fatal("rss format not registered")
+	}
+	assert.Equal(t, gofeed.FeedTypeRSS, rssFormat.Type)
+
+	wrapped := rssFormat
+	wrapped.Name = "test-rss-wrapped"
+	wrapped.Priority = 30
+	wrapped.Detect = func(prefix []byte) bool {
+		return rssFormat.Detect(prefix) && bytes.Contains(prefix, []byte("wrapped"))
+	}
+	feedType := gofeed.RegisterFormat(wrapped)
+	defer gofeed.RegisterFormat(gofeed.Format{
+		Name:       wrapped.Name,
+		Detect:     func(prefix []byte) bool { return false },
+		Parse:      parseTwtxt,
+		Translator: twtxtTranslator{},
+	})
+
+	doc := `<rss version="2.0"><channel><title>wrapped</title><item>` +
+		`<pubDate>Sat, 05 Oct 2024 14:30:00 +0200</pubDate></item></channel></rss>`
+	assert.Equal(t, feedType, gofeed.DetectFeedType(strings.NewReader(doc)))
+
+	fp := gofeed.NewParser()
+	fp.PreserveTimeZone = true
+	feed, err := fp.ParseString(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "rss", feed.FeedType)
+	_, offset := feed.Items[0].PublishedParsed.Zone()
+	assert.Equal(t, 2*60*60, offset)
+
+	// A labeled document Detect rejects is still narrowed by Labeled.
+	pad := "<!-- " + strings.Repeat("x", 8192) + " -->"
+	feed, err = fp.ParseWithContentType(strings.NewReader(pad+doc), "application/rss+xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "wrapped", feed.Title)
+	_, err = fp.ParseWithContentType(strings.NewReader(`{"title": "wrapped"}`), "application/rss+xml")
+	assert.ErrorIs(t, err, gofeed.ErrFeedTypeNotDetected)
+}
+
+// The per-format translator fields of a Parser still take precedence over
+// the registered format's translator.
+func TestRegisterFormat_ParserTranslatorOverride(t *testing.T) {
+	fp := gofeed.NewParser()
+	fp.RSSTranslator = twtxtTranslator{}
+	_, err := fp.ParseString(`<rss version="2.0"><channel></channel></rss>`)
+	assert.EqualError(t, err, "Feed did not match expected type of *twtxt")
+}
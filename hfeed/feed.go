@@ -0,0 +1,60 @@
+package hfeed
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Feed is a microformats2 h-feed: the h-entry items of an HTML page and
+// the properties of the h-feed around them. A page with h-entry items but
+// no h-feed is read as an implied feed named after the page title.
+// http://microformats.org/wiki/h-feed
+type Feed struct {
+	Name    string   `json:"name,omitempty"`
+	URL     string   `json:"url,omitempty"`
+	UID     string   `json:"uid,omitempty"`
+	Summary string   `json:"summary,omitempty"`
+	Photo   string   `json:"photo,omitempty"`
+	Author  *Card    `json:"author,omitempty"`
+	Entries []*Entry `json:"entries"`
+}
+
+func (f Feed) String() string {
+	json, _ := json.MarshalIndent(f, "", "    ")
+	return string(json)
+}
+
+// Entry is a microformats2 h-entry.
+// http://microformats.org/wiki/h-entry
+type Entry struct {
+	// Name is the p-name, or the implied name: the entry's whole text when
+	// it has no p-name and no other p-* or e-* properties, as is usual for
+	// notes.
+	Name            string     `json:"name,omitempty"`
+	Summary         string     `json:"summary,omitempty"`
+	Content         *Content   `json:"content,omitempty"`
+	Published       string     `json:"published,omitempty"`
+	PublishedParsed *time.Time `json:"publishedParsed,omitempty"`
+	Updated         string     `json:"updated,omitempty"`
+	UpdatedParsed   *time.Time `json:"updatedParsed,omitempty"`
+	URL             string     `json:"url,omitempty"`
+	UID             string     `json:"uid,omitempty"`
+	Authors         []*Card    `json:"authors,omitempty"`
+	Photos          []string   `json:"photos,omitempty"`
+	Categories      []string   `json:"categories,omitempty"`
+}
+
+// Content is an e-content property: the element's inner HTML and its
+// plain text.
+type Content struct {
+	HTML  string `json:"html,omitempty"`
+	Value string `json:"value,omitempty"`
+}
+
+// Card is an h-card, or the plain text of an author without one.
+// http://microformats.org/wiki/h-card
+type Card struct {
+	Name  string `json:"name,omitempty"`
+	URL   string `json:"url,omitempty"`
+	Photo string `json:"photo,omitempty"`
+}
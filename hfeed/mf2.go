@@ -0,0 +1,380 @@
+package hfeed
+
+import (
+	"bytes"
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// item is a parsed microformats2 item: its h-* types, its properties and
+// the items nested in it without a property class.
+type item struct {
+	types    []string
+	props    map[string][]value
+	children []*item
+}
+
+// value is one property value: text, the inner HTML of an e-* property,
+// or a nested item.
+type value struct {
+	text string
+	html string
+	item *item
+}
+
+func (it *item) is(typ string) bool {
+	for _, t := range it.types {
+		if t == typ {
+			return true
+		}
+	}
+	return false
+}
+
+// text returns the first text value of a property.
+func (it *item) text(name string) string {
+	for _, v := range it.props[name] {
+		return v.text
+	}
+	return ""
+}
+
+// texts returns the text values of a property.
+func (it *item) texts(name string) (texts []string) {
+	for _, v := range it.props[name] {
+		if v.text != "" {
+			texts = append(texts, v.text)
+		}
+	}
+	return
+}
+
+// mf2 parses microformats2 items from an HTML tree.
+type mf2 struct {
+	base *url.URL
+}
+
+// items returns the top-level items under n.
+func (m *mf2) items(n *html.Node) (items []*item) {
+	if n.Type == html.ElementNode && len(rootClasses(n)) > 0 {
+		return []*item{m.parseItem(n)}
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		items = append(items, m.items(c)...)
+	}
+	return
+}
+
+func (m *mf2) parseItem(n *html.Node) *item {
+	it := &item{types: rootClasses(n), props: map[string][]value{}}
+	var explicit struct{ p, u, e, nested bool }
+
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		if n.Type != html.ElementNode {
+			return
+		}
+		props := propertyClasses(n)
+		for _, prop := range props {
+			switch prop.prefix {
+			case "p":
+				explicit.p = true
+			case "u":
+				explicit.u = true
+			case "e":
+				explicit.e = true
+			}
+		}
+
+		if len(rootClasses(n)) > 0 {
+			child := m.parseItem(n)
+			explicit.nested = true
+			if len(props) == 0 {
+				it.children = append(it.children, child)
+			}
+			for _, prop := range props {
+				v := value{item: child}
+				switch prop.prefix {
+				case "u":
+					v.text = child.text("url")
+				case "e":
+					v.text, v.html = textContent(n), innerHTML(n)
+				default:
+					v.text = child.text("name")
+				}
+				if v.text == "" {
+					v.text = m.parseValue(prop.prefix, n).text
+				}
+				it.props[prop.name] = append(it.props[prop.name], v)
+			}
+			return
+		}
+
+		for _, prop := range props {
+			it.props[prop.name] = append(it.props[prop.name], m.parseValue(prop.prefix, n))
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		walk(c)
+	}
+
+	// Implied properties, for items such as <a class="h-card" href="/">Jane</a>.
+	if _, ok := it.props["name"]; !ok && !explicit.p && !explicit.e && !explicit.nested {
+		it.props["name"] = []value{{text: impliedName(n)}}
+	}
+	if _, ok := it.props["photo"]; !ok && !explicit.u {
+		if src := m.impliedAttr(n, "src", atom.Img); src != "" {
+			it.props["photo"] = []value{{text: src}}
+		}
+	}
+	if _, ok := it.props["url"]; !ok && !explicit.u {
+		if href := m.impliedAttr(n, "href", atom.A, atom.Area); href != "" {
+			it.props["url"] = []value{{text: href}}
+		}
+	}
+	return it
+}
+
+// parseValue parses a property value by its class prefix.
+func (m *mf2) parseValue(prefix string, n *html.Node) value {
+	switch prefix {
+	case "u":
+		for _, attr := range urlAttrs[n.DataAtom] {
+			if v, ok := attribute(n, attr); ok {
+				return value{text: m.resolve(v)}
+			}
+		}
+		if v, ok := valueAttr(n); ok {
+			return value{text: m.resolve(v)}
+		}
+		return value{text: m.resolve(textContent(n))}
+	case "dt":
+		if n.DataAtom == atom.Time || n.DataAtom == atom.Ins || n.DataAtom == atom.Del {
+			if v, ok := attribute(n, "datetime"); ok {
+				return value{text: strings.TrimSpace(v)}
+			}
+		}
+		if v, ok := valueAttr(n); ok {
+			return value{text: strings.TrimSpace(v)}
+		}
+	case "e":
+		return value{text: textContent(n), html: innerHTML(n)}
+	case "p":
+		if v, ok := valueAttr(n); ok {
+			return value{text: v}
+		}
+		if n.DataAtom == atom.Img || n.DataAtom == atom.Area {
+			if v, ok := attribute(n, "alt"); ok {
+				return value{text: v}
+			}
+		}
+	}
+	return value{text: textContent(n)}
+}
+
+// urlAttrs are the attributes holding a u-* property's URL, by element.
+var urlAttrs = map[atom.Atom][]string{
+	atom.A:      {"href"},
+	atom.Area:   {"href"},
+	atom.Link:   {"href"},
+	atom.Img:    {"src"},
+	atom.Audio:  {"src"},
+	atom.Source: {"src"},
+	atom.Iframe: {"src"},
+	atom.Video:  {"src", "poster"},
+	atom.Object: {"data"},
+}
+
+// valueAttr returns the value of an abbr title or a data or input value.
+func valueAttr(n *html.Node) (string, bool) {
+	switch n.DataAtom {
+	case atom.Abbr:
+		return attribute(n, "title")
+	case atom.Data, atom.Input:
+		return attribute(n, "value")
+	}
+	return "", false
+}
+
+// impliedName implies an item's name from an image's alt text, an abbr
+// title, or its text.
+func impliedName(n *html.Node) string {
+	if n.DataAtom == atom.Img || n.DataAtom == atom.Area {
+		if v, ok := attribute(n, "alt"); ok {
+			return v
+		}
+	}
+	if v, ok := attribute(n, "title"); ok && n.DataAtom == atom.Abbr {
+		return v
+	}
+	if child := onlyChild(n); child != nil && child.DataAtom == atom.Img && len(rootClasses(child)) == 0 {
+		if v, ok := attribute(child, "alt"); ok {
+			return v
+		}
+	}
+	return textContent(n)
+}
+
+// impliedAttr implies a URL from the attribute of the element itself or of
+// its only child, when they are one of elems.
+func (m *mf2) impliedAttr(n *html.Node, attr string, elems ...atom.Atom) string {
+	for _, candidate := range []*html.Node{n, onlyChild(n)} {
+		if candidate == nil || (candidate != n && len(rootClasses(candidate)) > 0) {
+			continue
+		}
+		for _, elem := range elems {
+			if candidate.DataAtom != elem {
+				continue
+			}
+			if v, ok := attribute(candidate, attr); ok {
+				return m.resolve(v)
+			}
+		}
+	}
+	return ""
+}
+
+// resolve resolves a URL against the document's base URL.
+func (m *mf2) resolve(u string) string {
+	u = strings.TrimSpace(u)
+	if m.base == nil || u == "" {
+		return u
+	}
+	ref, err := url.Parse(u)
+	if err != nil {
+		return u
+	}
+	return m.base.ResolveReference(ref).String()
+}
+
+// onlyChild returns the only element child of n, or nil.
+func onlyChild(n *html.Node) *html.Node {
+	var only *html.Node
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		switch {
+		case c.Type == html.ElementNode:
+			if only != nil {
+				return nil
+			}
+			only = c
+		case c.Type == html.TextNode && strings.TrimSpace(c.Data) != "":
+			return nil
+		}
+	}
+	return only
+}
+
+type propertyClass struct {
+	prefix, name string
+}
+
+// rootClasses returns the h-* classes of n.
+func rootClasses(n *html.Node) (roots []string) {
+	for _, class := range classes(n) {
+		if name, ok := strings.CutPrefix(class, "h-"); ok && isClassName(name) {
+			roots = append(roots, class)
+		}
+	}
+	return
+}
+
+// propertyClasses returns the p-*, u-*, dt-* and e-* classes of n.
+func propertyClasses(n *html.Node) (props []propertyClass) {
+	for _, class := range classes(n) {
+		prefix, name, ok := strings.Cut(class, "-")
+		if !ok || !isClassName(name) {
+			continue
+		}
+		switch prefix {
+		case "p", "u", "dt", "e":
+			props = append(props, propertyClass{prefix, name})
+		}
+	}
+	return
+}
+
+// utilityClasses are the names of CSS framework utility classes, such as
+// Bootstrap's h-auto and Tailwind's h-full or p-px, that are shaped like
+// microformats2 class names but never mean one.
+var utilityClasses = map[string]bool{
+	"auto":   true,
+	"dvh":    true,
+	"fit":    true,
+	"full":   true,
+	"lvh":    true,
+	"max":    true,
+	"min":    true,
+	"px":     true,
+	"screen": true,
+	"svh":    true,
+}
+
+// isClassName reports whether name, the part of a class after its h-, p-,
+// u-, dt- or e- prefix, is a microformats2 name: lowercase words joined by
+// hyphens. This leaves out CSS utility classes such as Bootstrap's h-100
+// and p-3, which would otherwise open items and properties of their own.
+func isClassName(name string) bool {
+	if utilityClasses[name] {
+		return false
+	}
+	for _, word := range strings.Split(name, "-") {
+		if word == "" {
+			return false
+		}
+		for _, r := range word {
+			if r < 'a' || r > 'z' {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func classes(n *html.Node) []string {
+	class, _ := attribute(n, "class")
+	return strings.Fields(class)
+}
+
+func attribute(n *html.Node, name string) (string, bool) {
+	for _, attr := range n.Attr {
+		if attr.Namespace == "" && attr.Key == name {
+			return attr.Val, true
+		}
+	}
+	return "", false
+}
+
+// textContent returns the trimmed text of n, leaving out scripts and
+// styles.
+func textContent(n *html.Node) string {
+	var b strings.Builder
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		switch {
+		case n.Type == html.TextNode:
+			b.WriteString(n.Data)
+		case n.DataAtom == atom.Script || n.DataAtom == atom.Style:
+		default:
+			for c := n.FirstChild; c != nil; c = c.NextSibling {
+				walk(c)
+			}
+		}
+	}
+	walk(n)
+	return strings.TrimSpace(b.String())
+}
+
+// innerHTML renders the children of n.
+func innerHTML(n *html.Node) string {
+	var b bytes.Buffer
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		html.Render(&b, c)
+	}
+	return strings.TrimSpace(b.String())
+}
@@ -0,0 +1,167 @@
+package hfeed
+
+import (
+	"errors"
+	"io"
+	"net/url"
+
+	"github.com/mmcdole/gofeed/internal/shared"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// ErrNoFeed is returned by Parse for a page with neither an h-feed nor
+// top-level h-entry items.
+var ErrNoFeed = errors.New("hfeed: no h-feed or h-entry found")
+
+// Parser is an h-feed Parser
+type Parser struct {
+	// PreserveTimeZone keeps the offset dates are written with in
+	// PublishedParsed and UpdatedParsed, which are otherwise normalized
+	// to UTC.
+	PreserveTimeZone bool
+}
+
+// Parse parses an HTML page into an hfeed.Feed. The first h-feed on the
+// page is used; without one, the page's top-level h-entry items make up
+// a feed named after the page title.
+func (hp *Parser) Parse(page io.Reader) (*Feed, error) {
+	doc, err := html.Parse(page)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &mf2{}
+	if href, ok := findBase(doc); ok {
+		if base, err := url.Parse(href); err == nil {
+			m.base = base
+		}
+	}
+	items := m.items(doc)
+
+	if item := findType(items, "h-feed"); item != nil {
+		return hp.parseFeed(item), nil
+	}
+
+	feed := &Feed{Name: findTitle(doc), Entries: []*Entry{}}
+	for _, item := range items {
+		if item.is("h-entry") {
+			feed.Entries = append(feed.Entries, hp.parseEntry(item))
+		}
+	}
+	if len(feed.Entries) == 0 {
+		return nil, ErrNoFeed
+	}
+	return feed, nil
+}
+
+func (hp *Parser) parseFeed(item *item) *Feed {
+	feed := &Feed{
+		Name:    item.text("name"),
+		URL:     item.text("url"),
+		UID:     item.text("uid"),
+		Summary: item.text("summary"),
+		Photo:   item.text("photo"),
+		Entries: []*Entry{},
+	}
+	if authors := parseCards(item.props["author"]); len(authors) > 0 {
+		feed.Author = authors[0]
+	}
+
+	// Entries are usually nested without a property class, though some
+	// pages mark them up as p-entry or u-entry.
+	entries := item.children
+	for _, v := range item.props["entry"] {
+		if v.item != nil {
+			entries = append(entries, v.item)
+		}
+	}
+	for _, child := range entries {
+		if child.is("h-entry") {
+			feed.Entries = append(feed.Entries, hp.parseEntry(child))
+		}
+	}
+	return feed
+}
+
+func (hp *Parser) parseEntry(item *item) *Entry {
+	entry := &Entry{
+		Name:       item.text("name"),
+		Summary:    item.text("summary"),
+		Published:  item.text("published"),
+		Updated:    item.text("updated"),
+		URL:        item.text("url"),
+		UID:        item.text("uid"),
+		Authors:    parseCards(item.props["author"]),
+		Photos:     item.texts("photo"),
+		Categories: item.texts("category"),
+	}
+	entry.PublishedParsed = shared.ParseDateTime(entry.Published, hp.PreserveTimeZone)
+	entry.UpdatedParsed = shared.ParseDateTime(entry.Updated, hp.PreserveTimeZone)
+
+	if values := item.props["content"]; len(values) > 0 {
+		entry.Content = &Content{HTML: values[0].html, Value: values[0].text}
+	}
+	return entry
+}
+
+// parseCards returns the authors of a property, whether h-card items or
+// plain text.
+func parseCards(values []value) (cards []*Card) {
+	for _, v := range values {
+		card := &Card{Name: v.text}
+		if v.item != nil {
+			card = &Card{
+				Name:  v.item.text("name"),
+				URL:   v.item.text("url"),
+				Photo: v.item.text("photo"),
+			}
+		}
+		if card.Name != "" || card.URL != "" {
+			cards = append(cards, card)
+		}
+	}
+	return
+}
+
+// findType returns the first item of type typ, searching items and their
+// children depth first.
+func findType(items []*item, typ string) *item {
+	for _, item := range items {
+		if item.is(typ) {
+			return item
+		}
+		if found := findType(item.children, typ); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// findBase returns the href of the page's first <base> element.
+func findBase(n *html.Node) (string, bool) {
+	if n.Type == html.ElementNode && n.DataAtom == atom.Base {
+		if href, ok := attribute(n, "href"); ok {
+			return href, true
+		}
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if href, ok := findBase(c); ok {
+			return href, true
+		}
+	}
+	return "", false
+}
+
+// findTitle returns the text of the page's <title>.
+func findTitle(n *html.Node) string {
+	if n.Type == html.ElementNode && n.DataAtom == atom.Title {
+		return textContent(n)
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if title := findTitle(c); title != "" {
+			return title
+		}
+	}
+	return ""
+}
@@ -0,0 +1,89 @@
+package hfeed_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mmcdole/gofeed/hfeed"
+	"github.com/stretchr/testify/assert"
+)
+
+// Tests
+
+func TestParser_Parse(t *testing.T) {
+	files, _ := filepath.Glob("../testdata/parser/hfeed/*.html")
+	for _, f := range files {
+		base := filepath.Base(f)
+		name := strings.TrimSuffix(base, filepath.Ext(base))
+
+		fmt.Printf("Testing %s... ", name)
+
+		// Get actual source page
+		ff := fmt.Sprintf("../testdata/parser/hfeed/%s.html", name)
+		f, _ := os.ReadFile(ff)
+
+		// Parse actual page
+		fp := &hfeed.Parser{}
+		actual, _ := fp.Parse(bytes.NewReader(f))
+
+		// Get json encoded expected feed result
+		ef := fmt.Sprintf("../testdata/parser/hfeed/%s.json", name)
+		e, _ := os.ReadFile(ef)
+
+		// Unmarshal expected feed
+		expected := &hfeed.Feed{}
+		json.Unmarshal(e, &expected)
+
+		if assert.Equal(t, expected, actual, "Feed file %s.html did not match expected output %s.json", name, name) {
+			fmt.Printf("OK\n")
+		} else {
+			fmt.Printf("Failed\n")
+		}
+	}
+}
+
+func TestParser_Parse_NoFeed(t *testing.T) {
+	fp := &hfeed.Parser{}
+	_, err := fp.Parse(strings.NewReader(`<html><body><div class="h-card"><p class="p-name">Jane</p></div></body></html>`))
+	assert.Equal(t, hfeed.ErrNoFeed, err)
+}
+
+// CSS utility classes shaped like microformats2 classes, such as
+// Bootstrap's h-100, h-auto and p-3, are neither items nor properties.
+func TestParser_Parse_UtilityClasses(t *testing.T) {
+	page := `<html><body><main class="container h-100">
+		<article class="h-entry"><div class="h-auto p-3"><h1 class="p-name">A</h1></div></article>
+	</main></body></html>`
+	feed, err := (&hfeed.Parser{}).Parse(strings.NewReader(page))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if assert.Len(t, feed.Entries, 1) {
+		assert.Equal(t, "A", feed.Entries[0].Name)
+	}
+}
+
+// Implied properties follow the microformats2 parsing rules: an h-card
+// with no explicit properties takes its name, url and photo from its
+// element and only child.
+func TestParser_Parse_ImpliedProperties(t *testing.T) {
+	page := `<html><body><div class="h-entry">
+		<span class="p-name">Post</span>
+		<a class="p-author h-card" href="https://example.org/jane">Jane</a>
+		<span class="p-author h-card"><img src="https://example.org/bob.png" alt="Bob"></span>
+	</div></body></html>`
+	feed, err := (&hfeed.Parser{}).Parse(strings.NewReader(page))
+	if err != nil {
+		t.Fatal(err)
+	}
+	authors := feed.Entries[0].Authors
+	assert.Equal(t, []*hfeed.Card{
+		{Name: "Jane", URL: "https://example.org/jane"},
+		{Name: "Bob", Photo: "https://example.org/bob.png"},
+	}, authors)
+}
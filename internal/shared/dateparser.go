@@ -254,3 +254,17 @@ func ParseDate(ds string) (t time.Time, err error) {
 	err = fmt.Errorf("failed to parse date: %s", ds)
 	return
 }
+
+// ParseDateTime parses ds for the Parsed field beside it: nil when ds is not
+// a date, and normalized to UTC unless preserveTimeZone is set, in which
+// case the offset ds is written with is kept.
+func ParseDateTime(ds string, preserveTimeZone bool) *time.Time {
+	t, err := ParseDate(ds)
+	if err != nil {
+		return nil
+	}
+	if !preserveTimeZone {
+		t = t.UTC()
+	}
+	return &t
+}
@@ -1,6 +1,8 @@
 package shared
 
 import (
+	"encoding/xml"
+	"io"
 	"strings"
 
 	"github.com/mmcdole/gofeed/extensions"
@@ -17,11 +19,20 @@ func IsExtension(p *xpp.Parser) bool {
 
 // ParseExtension parses the current element of the
 // XMLPullParser as an extension element and updates
-// the extension map
-func ParseExtension(fe ext.Extensions, p *xpp.Parser) (ext.Extensions, error) {
+// the extension map. When preserveXML is set, each
+// element also keeps its raw inner XML and its content
+// in document order.
+func ParseExtension(fe ext.Extensions, p *xpp.Parser, preserveXML bool) (ext.Extensions, error) {
 	prefix := PrefixForNamespace(p.Space(), p)
+	name := p.Name()
 
-	result, err := parseExtensionElement(p)
+	var result ext.Extension
+	var err error
+	if preserveXML {
+		result, err = parseRawExtensionElement(p)
+	} else {
+		result, err = parseExtensionElement(p)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -31,11 +42,11 @@ func ParseExtension(fe ext.Extensions, p *xpp.Parser) (ext.Extensions, error) {
 		fe[prefix] = map[string][]ext.Extension{}
 	}
 	// Ensure the extension element slice exists
-	if _, ok := fe[prefix][p.Name()]; !ok {
-		fe[prefix][p.Name()] = []ext.Extension{}
+	if _, ok := fe[prefix][name]; !ok {
+		fe[prefix][name] = []ext.Extension{}
 	}
 
-	fe[prefix][p.Name()] = append(fe[prefix][p.Name()], result)
+	fe[prefix][name] = append(fe[prefix][name], result)
 	return fe, nil
 }
 
@@ -44,15 +55,7 @@ func parseExtensionElement(p *xpp.Parser) (e ext.Extension, err error) {
 		return e, err
 	}
 
-	e.Name = p.Name()
-	e.Children = map[string][]ext.Extension{}
-	e.Attrs = map[string]string{}
-
-	for _, attr := range p.Attrs() {
-		// TODO: Alright that we are stripping
-		// namespace information from attributes ?
-		e.Attrs[attr.Name.Local] = attr.Value
-	}
+	e = newExtension(p)
 
 	for {
 		tok, err := p.Next()
@@ -89,13 +92,136 @@ func parseExtensionElement(p *xpp.Parser) (e ext.Extension, err error) {
 	return e, nil
 }
 
+// parseRawExtensionElement parses an extension element like
+// parseExtensionElement, additionally keeping its inner XML exactly as
+// written and its text, comments and child elements in document order.
+//
+// The inner XML is captured with DecodeElement, then parsed once inside a
+// wrapper that redeclares the namespaces in scope so that prefixes bound on
+// ancestors still resolve. The inner XML of each descendant is sliced out of
+// that copy by the decoder's offsets, so nothing is read twice however deep
+// the tree.
+func parseRawExtensionElement(p *xpp.Parser) (e ext.Extension, err error) {
+	if err = p.Expect(xpp.StartTag, "*"); err != nil {
+		return e, err
+	}
+
+	e = newExtension(p)
+	namespaces := p.Namespaces()
+
+	var raw struct {
+		InnerXML string `xml:",innerxml"`
+	}
+	if err = p.DecodeElement(&raw); err != nil {
+		return e, err
+	}
+
+	var wrapper strings.Builder
+	wrapper.WriteString("<wrapper")
+	for prefix, uri := range namespaces {
+		if prefix == "" {
+			wrapper.WriteString(` xmlns="`)
+		} else if prefix == "xml" || prefix == "xmlns" {
+			continue
+		} else {
+			wrapper.WriteString(" xmlns:" + prefix + `="`)
+		}
+		xml.EscapeText(&wrapper, []byte(uri))
+		wrapper.WriteString(`"`)
+	}
+	wrapper.WriteString(">" + raw.InnerXML + "</wrapper>")
+	src := wrapper.String()
+
+	inner := NewXMLParser(strings.NewReader(src))
+	if _, err = inner.NextTag(); err != nil {
+		return e, err
+	}
+	err = parseRawContent(inner, src, &e)
+	return e, err
+}
+
+// parseRawContent reads the content of the element whose start tag inner
+// is on, through its end tag, into e. src is the whole input of inner.
+func parseRawContent(inner *xpp.Parser, src string, e *ext.Extension) error {
+	start := inner.InputOffset()
+	for {
+		end := inner.InputOffset()
+		tok, err := inner.NextToken()
+		if err != nil {
+			return err
+		}
+
+		switch tok {
+		case xpp.EndTag:
+			e.InnerXML = src[start:end]
+			e.Value = strings.TrimSpace(e.Value)
+			return nil
+		case xpp.EndDocument:
+			return io.ErrUnexpectedEOF
+		case xpp.StartTag:
+			child := newExtension(inner)
+			if err := parseRawContent(inner, src, &child); err != nil {
+				return err
+			}
+			e.Children[child.Name] = append(e.Children[child.Name], child)
+			e.Content = append(e.Content, ext.Node{Type: ext.ElementNode, Element: &child})
+		case xpp.Text:
+			e.Value += inner.Text()
+			if n := len(e.Content); n > 0 && e.Content[n-1].Type == ext.TextNode {
+				e.Content[n-1].Text += inner.Text()
+			} else {
+				e.Content = append(e.Content, ext.Node{Type: ext.TextNode, Text: inner.Text()})
+			}
+		case xpp.Comment:
+			e.Content = append(e.Content, ext.Node{Type: ext.CommentNode, Text: inner.Text()})
+		}
+	}
+}
+
+// newExtension returns an extension for the element at the parser's
+// current start tag, with its name, namespace and attributes.
+func newExtension(p *xpp.Parser) ext.Extension {
+	e := ext.Extension{
+		Name:      p.Name(),
+		Namespace: strings.TrimSpace(p.Space()),
+		Children:  map[string][]ext.Extension{},
+		Attrs:     map[string]string{},
+	}
+
+	for _, attr := range p.Attrs() {
+		// Attrs stays keyed by local name; namespaced attributes are
+		// additionally recorded with their namespace URI.
+		e.Attrs[attr.Name.Local] = attr.Value
+
+		space := strings.TrimSpace(attr.Name.Space)
+		if space == "" || space == "xmlns" {
+			continue
+		}
+		if e.AttrsNS == nil {
+			e.AttrsNS = map[string]map[string]string{}
+		}
+		if _, ok := e.AttrsNS[space]; !ok {
+			e.AttrsNS[space] = map[string]string{}
+		}
+		e.AttrsNS[space][attr.Name.Local] = attr.Value
+	}
+	return e
+}
+
 func PrefixForNamespace(space string, p *xpp.Parser) string {
 	// Namespace attribute values may legally carry surrounding whitespace.
 	// Trim here, once, so every lookup below (and every caller) agrees on
 	// the key.
 	space = strings.TrimSpace(space)
 
-	// First we check if the global namespace map
+	// Namespaces registered with ext.Register are stored under the
+	// prefix they were registered with, which is where ext.Get looks for
+	// them, even when it differs from the canonical one.
+	if prefix, ok := ext.RegisteredPrefix(space); ok {
+		return prefix
+	}
+
+	// Next we check if the global namespace map
 	// contains an entry for this namespace/prefix.
 	// This way we can use the canonical prefix for this
 	// ns instead of the one defined in the feed.
@@ -119,11 +245,13 @@ func PrefixForNamespace(space string, p *xpp.Parser) string {
 // These are used for determining canonical name space prefixes
 // for many of the popular RSS/Atom extensions.
 //
-// These canonical prefixes override any prefixes used in the feed itself.
+// These canonical prefixes override any prefixes used in the feed itself,
+// but not the prefixes namespaces are registered with by ext.Register.
 var canonicalNamespaces = map[string]string{
 	"http://webns.net/mvcb/":                                         "admin",
 	"http://purl.org/rss/1.0/modules/aggregation/":                   "ag",
 	"http://purl.org/rss/1.0/modules/annotate/":                      "annotate",
+	"http://purl.org/atompub/tombstones/1.0":                         "at",
 	"http://media.tangent.org/rss/1.0/":                              "audio",
 	"http://backend.userland.com/blogChannelModule":                  "blogChannel",
 	"http://creativecommons.org/ns#license":                          "cc",
@@ -138,6 +266,7 @@ var canonicalNamespaces = map[string]string{
 	"http://purl.org/rss/1.0/modules/email/":                         "email",
 	"http://purl.org/rss/1.0/modules/event/":                         "ev",
 	"http://rssnamespace.org/feedburner/ext/1.0":                     "feedburner",
+	"http://purl.org/syndication/history/1.0":                        "fh",
 	"http://freshmeat.net/rss/fm/":                                   "fm",
 	"http://xmlns.com/foaf/0.1/":                                     "foaf",
 	"http://www.w3.org/2003/01/geo/wgs84_pos#":                       "geo",
@@ -146,6 +275,7 @@ var canonicalNamespaces = map[string]string{
 	"http://postneo.com/icbm/":                                       "icbm",
 	"http://purl.org/rss/1.0/modules/image/":                         "image",
 	"http://www.itunes.com/DTDs/PodCast-1.0.dtd":                     "itunes",
+	"http://www.itunes.com/dtds/podcast-1.0.dtd":                     "itunes",
 	"http://example.com/DTDs/PodCast-1.0.dtd":                        "itunes",
 	"http://purl.org/rss/1.0/modules/link/":                          "l",
 	"http://search.yahoo.com/mrss":                                   "media",
@@ -166,6 +296,7 @@ var canonicalNamespaces = map[string]string{
 	"http://schemas.pocketsoap.com/rss/myDescModule/":                "szf",
 	"http://purl.org/rss/1.0/modules/taxonomy/":                      "taxo",
 	"http://purl.org/rss/1.0/modules/threading/":                     "thr",
+	"http://purl.org/syndication/thread/1.0":                         "thr",
 	"http://purl.org/rss/1.0/modules/textinput/":                     "ti",
 	"http://madskills.com/public/xml/rss/module/trackback/":          "trackback",
 	"http://wellformedweb.org/commentAPI/":                           "wfw",
@@ -76,7 +76,7 @@ func TestParseExtension(t *testing.T) {
 			break
 		}
 		if tok == xpp.StartTag && IsExtension(p) {
-			extensions, err = ParseExtension(extensions, p)
+			extensions, err = ParseExtension(extensions, p, false)
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -106,6 +106,65 @@ func TestParseExtension(t *testing.T) {
 	}
 }
 
+func TestParseExtensionNamespaces(t *testing.T) {
+	// m: is Media RSS under a non-canonical prefix, and x: is bound to two
+	// different namespaces in sibling scopes.
+	doc := `<rss xmlns:m="http://search.yahoo.com/mrss/" xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#">
+		<channel>
+			<m:thumbnail url="http://example.org/t.jpg"/>
+			<x:thing xmlns:x="http://example.org/one">1</x:thing>
+			<x:thing xmlns:x="http://example.org/two" rdf:resource="http://example.org/r">2</x:thing>
+		</channel>
+	</rss>`
+
+	p := NewXMLParser(strings.NewReader(doc))
+	extensions := ext.Extensions{}
+	for {
+		tok, err := p.NextToken()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if tok == xpp.EndDocument {
+			break
+		}
+		if tok == xpp.StartTag && IsExtension(p) {
+			extensions, err = ParseExtension(extensions, p, false)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	thumb := extensions["media"]["thumbnail"]
+	if len(thumb) != 1 || thumb[0].Namespace != "http://search.yahoo.com/mrss/" {
+		t.Fatalf("media thumbnail = %+v, want one element in the mrss namespace", thumb)
+	}
+
+	one := extensions.Namespace("http://example.org/one")["thing"]
+	two := extensions.Namespace("http://example.org/two")["thing"]
+	if len(one) != 1 || one[0].Value != "1" {
+		t.Errorf("namespace one = %+v, want value 1", one)
+	}
+	if len(two) != 1 || two[0].Value != "2" {
+		t.Fatalf("namespace two = %+v, want value 2", two)
+	}
+	if extensions.Namespace("http://example.org/none") != nil {
+		t.Errorf("unknown namespace should have no elements")
+	}
+
+	// Namespaced attributes keep their namespace and stay reachable by
+	// local name.
+	if got, ok := two[0].AttrNS("http://www.w3.org/1999/02/22-rdf-syntax-ns#", "resource"); !ok || got != "http://example.org/r" {
+		t.Errorf("AttrNS(rdf, resource) = %q, %v", got, ok)
+	}
+	if got := two[0].Attrs["resource"]; got != "http://example.org/r" {
+		t.Errorf("Attrs[resource] = %q", got)
+	}
+	if _, ok := two[0].AttrsNS["xmlns"]; ok {
+		t.Errorf("namespace declarations should not be recorded as attributes")
+	}
+}
+
 func TestPrefixForNamespace(t *testing.T) {
 	doc := `<rss xmlns:pod="http://www.itunes.com/DTDs/PodCast-1.0.dtd" xmlns:custom="http://example.org/ns">
 		<channel><pod:author>a</pod:author></channel>
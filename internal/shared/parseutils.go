@@ -20,6 +20,25 @@ var (
 const CDATA_START = "<![CDATA["
 const CDATA_END = "]]>"
 
+const (
+	// AppNS is the AtomPub (RFC 5023) namespace.
+	AppNS = "http://www.w3.org/2007/app"
+	// AppDraftNS is the namespace of the drafts that preceded RFC 5023,
+	// still served by some older servers.
+	AppDraftNS = "http://purl.org/atom/app#"
+)
+
+// IsAppNamespace reports whether space is an AtomPub namespace, of RFC 5023
+// or of the drafts before it. Surrounding whitespace is ignored, as in
+// PrefixForNamespace.
+func IsAppNamespace(space string) bool {
+	switch strings.TrimSpace(space) {
+	case AppNS, AppDraftNS:
+		return true
+	}
+	return false
+}
+
 // FindRoot iterates through the tokens of an xml document until
 // it encounters its first StartTag event.  It returns an error
 // if it reaches EndDocument before finding a tag.
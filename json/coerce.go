@@ -5,6 +5,8 @@ import (
 	"math"
 	"strconv"
 	"strings"
+
+	ext "github.com/mmcdole/gofeed/extensions"
 )
 
 // The JSON Feed spec asks readers to be liberal about a few field types. An id
@@ -16,32 +18,58 @@ import (
 // every field normally, while the loose fields are pulled out as raw JSON and
 // coerced by hand.
 
+// The members of feeds and items are decoded one at a time by decodeObject,
+// which picks out the extension objects as it goes. Their fields shadow
+// Extensions, so that a member named "extensions", which a feed may use
+// for anything, is not taken for them.
+
+// itemFields is what an Item's members are decoded into.
+type itemFields struct {
+	ID         json.RawMessage `json:"id"`
+	Extensions json.RawMessage `json:"extensions"`
+	*itemAlias
+}
+
+type itemAlias Item
+
 func (i *Item) UnmarshalJSON(data []byte) error {
-	type alias Item
-	aux := &struct {
-		ID json.RawMessage `json:"id"`
-		*alias
-	}{alias: (*alias)(i)}
-	if err := json.Unmarshal(data, aux); err != nil {
+	aux := &itemFields{itemAlias: (*itemAlias)(i)}
+	extensions, err := decodeObject(data, aux)
+	if err != nil {
 		return err
 	}
 	i.ID = coerceString(aux.ID)
+	i.Extensions = extensions
 	return nil
 }
 
+// feedFields is what a Feed's members, other than its items when they are
+// streamed, are decoded into.
+type feedFields struct {
+	Expired    json.RawMessage `json:"expired"`
+	Extensions json.RawMessage `json:"extensions"`
+	*feedAlias
+}
+
+type feedAlias Feed
+
 func (f *Feed) UnmarshalJSON(data []byte) error {
-	type alias Feed
-	aux := &struct {
-		Expired json.RawMessage `json:"expired"`
-		*alias
-	}{alias: (*alias)(f)}
-	if err := json.Unmarshal(data, aux); err != nil {
+	aux := &feedFields{feedAlias: (*feedAlias)(f)}
+	extensions, err := decodeObject(data, aux)
+	if err != nil {
 		return err
 	}
-	f.Expired = coerceBool(aux.Expired)
+	aux.finish(f, extensions)
 	return nil
 }
 
+// finish coerces the loose fields of a Feed once all its members are
+// decoded.
+func (aux *feedFields) finish(f *Feed, extensions ext.Extensions) {
+	f.Expired = coerceBool(aux.Expired)
+	f.Extensions = extensions
+}
+
 func (a *Attachments) UnmarshalJSON(data []byte) error {
 	type alias Attachments
 	aux := &struct {
@@ -0,0 +1,135 @@
+package json
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	ext "github.com/mmcdole/gofeed/extensions"
+)
+
+// decodeObject decodes the members of the JSON object data one at a time,
+// in a single pass; see decodeMember. It returns the extension objects it
+// found, or nil when there are none.
+func decodeObject(data []byte, v interface{}) (ext.Extensions, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	if tok == nil {
+		return nil, nil
+	}
+	if tok != json.Delim('{') {
+		// Not an object: json.Unmarshal reports the type error.
+		return nil, json.Unmarshal(data, v)
+	}
+
+	var extensions ext.Extensions
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		key, _ := tok.(string)
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return nil, err
+		}
+		if err := decodeMember(key, raw, v, &extensions); err != nil {
+			return nil, err
+		}
+	}
+	return extensions, nil
+}
+
+// decodeMember decodes one member of a feed or item object. A member whose
+// key starts with an underscore is an extension object and is added to
+// extensions under its key without the underscore, so "_itunes" is found at
+// Extensions["itunes"], in the same shape XML extensions take: object
+// members become elements (arrays repeat them), nested objects become
+// children and scalars become element values. A member that is not an
+// object is stored as a single element named after the extension.
+//
+// Any other member is decoded into v as json.Unmarshal decodes an object
+// holding just that member, so keys still match fields case-insensitively.
+func decodeMember(key string, raw json.RawMessage, v interface{}, extensions *ext.Extensions) error {
+	prefix := strings.TrimPrefix(key, "_")
+	if prefix == key || prefix == "" {
+		name, err := json.Marshal(key)
+		if err != nil {
+			return err
+		}
+		member := make([]byte, 0, len(name)+len(raw)+3)
+		member = append(append(append(append(member, '{'), name...), ':'), raw...)
+		return json.Unmarshal(append(member, '}'), v)
+	}
+
+	value, err := decodeValue(raw)
+	if err != nil {
+		return err
+	}
+
+	elements := map[string][]ext.Extension{}
+	if object, ok := value.(map[string]interface{}); ok {
+		for name, v := range object {
+			if values := extensionsFromValue(name, v); len(values) > 0 {
+				elements[name] = values
+			}
+		}
+	} else {
+		elements[prefix] = extensionsFromValue(prefix, value)
+	}
+
+	if *extensions == nil {
+		*extensions = ext.Extensions{}
+	}
+	(*extensions)[prefix] = elements
+	return nil
+}
+
+// decodeValue decodes raw JSON, keeping numbers as written.
+func decodeValue(raw json.RawMessage) (interface{}, error) {
+	d := json.NewDecoder(bytes.NewReader(raw))
+	d.UseNumber()
+	var v interface{}
+	if err := d.Decode(&v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// extensionsFromValue converts one JSON value to the extension elements it
+// stands for: one per array entry, none for null, which is dropped.
+func extensionsFromValue(name string, value interface{}) []ext.Extension {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case []interface{}:
+		var elements []ext.Extension
+		for _, entry := range v {
+			elements = append(elements, extensionsFromValue(name, entry)...)
+		}
+		return elements
+	}
+
+	e := ext.Extension{
+		Name:     name,
+		Attrs:    map[string]string{},
+		Children: map[string][]ext.Extension{},
+	}
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for childName, child := range v {
+			if children := extensionsFromValue(childName, child); len(children) > 0 {
+				e.Children[childName] = children
+			}
+		}
+	case string:
+		e.Value = v
+	default:
+		e.Value = fmt.Sprint(v)
+	}
+	return []ext.Extension{e}
+}
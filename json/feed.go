@@ -1,6 +1,10 @@
 package json
 
-import "encoding/json"
+import (
+	"encoding/json"
+
+	ext "github.com/mmcdole/gofeed/extensions"
+)
 
 // Feed describes the structure for JSON Feed v1.0
 // https://www.jsonfeed.org/version/1/
@@ -17,8 +21,14 @@ type Feed struct {
 	Author      *Author `json:"author,omitempty"`        // author (optional, object) specifies the feed author. The author object has several members. These are all optional — but if you provide an author object, then at least one is required:
 	Expired     bool    `json:"expired,omitempty"`       // expired (optional, boolean) says whether or not the feed is finished — that is, whether or not it will ever update again.
 	Items       []*Item `json:"items"`                   // items is an array, and is required
-	// TODO Hubs // hubs (very optional, array of objects) describes endpoints that can be used to subscribe to real-time notifications from the publisher of this feed. Each object has a type and url, both of which are required. See the section “Subscribing to Real-time Notifications” below for details.
-	// TODO Extensions
+	Hubs        []*Hub  `json:"hubs,omitempty"`          // hubs (very optional, array of objects) describes endpoints that can be used to subscribe to real-time notifications from the publisher of this feed. Each object has a type and url, both of which are required. See the section “Subscribing to Real-time Notifications” below for details.
+
+	// Extensions holds the extension objects, the members whose key starts
+	// with an underscore (e.g. "_itunes"), keyed without the underscore.
+	// It is only ever filled from those members: a Feed marshals it under
+	// "extensions" for debugging, but a member of that name is not read
+	// back into it.
+	Extensions ext.Extensions `json:"extensions,omitempty"`
 
 	// Version 1.1
 	Authors  []*Author `json:"authors,omitempty"`
@@ -47,7 +57,9 @@ type Item struct {
 
 	Tags        []string       `json:"tags,omitempty"`        // tags (optional, array of strings) can have any plain text values you want. Tags tend to be just one word, but they may be anything.
 	Attachments *[]Attachments `json:"attachments,omitempty"` // attachments (optional, array) lists related resources. Podcasts, for instance, would include an attachment that’s an audio or video file. An individual item may have one or more attachments.
-	// TODO Extensions
+
+	// Extensions holds the item's extension objects; see Feed.Extensions.
+	Extensions ext.Extensions `json:"extensions,omitempty"`
 
 	// Version 1.1
 	Authors  []*Author `json:"authors,omitempty"`
@@ -69,3 +81,10 @@ type Attachments struct {
 	SizeInBytes       int64  `json:"size_in_bytes,omitempty"`       // size_in_bytes (optional, number) specifies how large the file is.
 	DurationInSeconds int64  `json:"duration_in_seconds,omitempty"` // duration_in_seconds (optional, number) specifies how long it takes to listen to or watch, when played at normal speed.
 }
+
+// Hub describes an endpoint that can be used to subscribe to real-time
+// notifications from the publisher of this feed.
+type Hub struct {
+	Type string `json:"type,omitempty"` // type (required, string) is the protocol used to talk with the hub, such as “rssCloud” or “WebSub.”
+	URL  string `json:"url,omitempty"`  // url (required, string) is the URL of the hub.
+}
@@ -1,25 +1,180 @@
 package json
 
 import (
-	"bytes"
 	"encoding/json"
+	"fmt"
 	"io"
+	"reflect"
+	"strings"
+
+	ext "github.com/mmcdole/gofeed/extensions"
 )
 
 // Parser is an JSON Feed Parser
 type Parser struct{}
 
 // Parse parses an json feed into an json.Feed
+//
+// The feed is decoded as a stream of tokens, member by member and item by
+// item, rather than copied into a buffer first. Every item is kept in
+// Feed.Items, so memory use still grows with the feed; Walk hands the
+// items over one at a time instead. As with json.Unmarshal, keys match
+// case-insensitively and nothing but whitespace may follow the feed object.
 func (ap *Parser) Parse(feed io.Reader) (*Feed, error) {
+	var items []*Item
+	jsonFeed, err := ap.parse(feed, func(dec *json.Decoder) (err error) {
+		items, err = parseItems(dec)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	jsonFeed.Items = items
+	return jsonFeed, nil
+}
+
+// Walk parses an json feed as Parse does, but calls fn with each item as it
+// is decoded instead of keeping it, so the returned Feed has no Items.
+// Memory use is then bounded by the largest member or item rather than by
+// the whole feed, which matters for large archive feeds. Members after the
+// items are still read into the returned Feed. An error from fn stops the
+// walk and is returned.
+func (ap *Parser) Walk(feed io.Reader, fn func(*Item) error) (*Feed, error) {
+	return ap.parse(feed, func(dec *json.Decoder) error {
+		_, err := walkItems(dec, fn)
+		return err
+	})
+}
+
+// parse decodes the feed object from feed, passing the decoder to items
+// when the value of its items member is next.
+func (ap *Parser) parse(feed io.Reader, items func(dec *json.Decoder) error) (*Feed, error) {
+	dec := json.NewDecoder(feed)
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, eofError(err)
+	}
+	if tok == nil {
+		return &Feed{}, nil
+	}
+	if tok != json.Delim('{') {
+		return nil, typeError(tok, reflect.TypeOf(Feed{}), "", dec)
+	}
+
+	// The members go through decodeMember, so that they are coerced and
+	// their extensions collected just as by Feed.UnmarshalJSON.
 	jsonFeed := &Feed{}
+	aux := &feedFields{feedAlias: (*feedAlias)(jsonFeed)}
+	var extensions ext.Extensions
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, eofError(err)
+		}
+		key, _ := tok.(string)
+		if strings.EqualFold(key, "items") {
+			if err := items(dec); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return nil, eofError(err)
+		}
+		if err := decodeMember(key, raw, aux, &extensions); err != nil {
+			return nil, err
+		}
+	}
+	if _, err := dec.Token(); err != nil {
+		return nil, eofError(err)
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		if err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("json: invalid data after top-level value at offset %d", dec.InputOffset())
+	}
 
-	buffer := new(bytes.Buffer)
-	if _, err := buffer.ReadFrom(feed); err != nil {
+	aux.finish(jsonFeed, extensions)
+	return jsonFeed, nil
+}
+
+// parseItems decodes the value of the items member into a slice, which
+// stays nil when the value is null, as with json.Unmarshal.
+func parseItems(dec *json.Decoder) ([]*Item, error) {
+	items := []*Item{}
+	array, err := walkItems(dec, func(item *Item) error {
+		items = append(items, item)
+		return nil
+	})
+	if err != nil || !array {
 		return nil, err
 	}
+	return items, nil
+}
 
-	if err := json.Unmarshal(buffer.Bytes(), jsonFeed); err != nil {
-		return nil, err
+// walkItems decodes the value of the items member one element at a time,
+// calling fn with each. It reports whether the value was an array rather
+// than null.
+func walkItems(dec *json.Decoder, fn func(*Item) error) (bool, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return false, eofError(err)
 	}
-	return jsonFeed, nil
+	if tok == nil {
+		return false, nil
+	}
+	if tok != json.Delim('[') {
+		return false, typeError(tok, reflect.TypeOf([]*Item{}), "items", dec)
+	}
+
+	for dec.More() {
+		item := &Item{}
+		if err := dec.Decode(item); err != nil {
+			return false, eofError(err)
+		}
+		if err := fn(item); err != nil {
+			return false, err
+		}
+	}
+	if _, err := dec.Token(); err != nil {
+		return false, eofError(err)
+	}
+	return true, nil
+}
+
+// typeError reports a value of the wrong type, the first token of which
+// has been read, as json.Unmarshal would.
+func typeError(tok json.Token, typ reflect.Type, field string, dec *json.Decoder) error {
+	var value string
+	switch tok.(type) {
+	case json.Delim:
+		value = "object"
+		if tok == json.Delim('[') {
+			value = "array"
+		}
+	case string:
+		value = "string"
+	case float64:
+		value = "number"
+	case bool:
+		value = "bool"
+	}
+	return &json.UnmarshalTypeError{
+		Value:  value,
+		Type:   typ,
+		Offset: dec.InputOffset(),
+		Field:  field,
+	}
+}
+
+// eofError turns the end of the input inside the feed into
+// io.ErrUnexpectedEOF: the decoder reports a document cut off between two
+// tokens as a plain io.EOF, which would read as a clean end of the input.
+func eofError(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
 }
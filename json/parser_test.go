@@ -12,6 +12,7 @@ import (
 	"testing"
 	"testing/iotest"
 
+	ext "github.com/mmcdole/gofeed/extensions"
 	jsonParser "github.com/mmcdole/gofeed/json"
 	"github.com/stretchr/testify/assert"
 )
@@ -45,8 +46,7 @@ func TestParser_Parse(t *testing.T) {
 		e, _ := os.ReadFile(ef)
 
 		// Unmarshal expected feed
-		expected := &jsonParser.Feed{}
-		json.Unmarshal(e, &expected)
+		expected := unmarshalExpected(e)
 
 		if assert.Equal(t, expected, actual, "Feed file %s.json did not match expected output %s.json", name, name) {
 			fmt.Printf("OK\n")
@@ -56,6 +56,27 @@ func TestParser_Parse(t *testing.T) {
 	}
 }
 
+// unmarshalExpected reads an expected feed. Extensions are only read from
+// the "_"-prefixed members of a feed, so the "extensions" of the expected
+// feed and its items, listed the way Feed marshals them, are restored here.
+func unmarshalExpected(data []byte) *jsonParser.Feed {
+	expected := &jsonParser.Feed{}
+	json.Unmarshal(data, expected)
+
+	var extensions struct {
+		Extensions ext.Extensions
+		Items      []struct{ Extensions ext.Extensions }
+	}
+	json.Unmarshal(data, &extensions)
+	expected.Extensions = extensions.Extensions
+	for i, item := range extensions.Items {
+		if i < len(expected.Items) {
+			expected.Items[i].Extensions = item.Extensions
+		}
+	}
+	return expected
+}
+
 // TODO: Remove redundant tests
 func TestParser_ParseInvalidAndStruct(t *testing.T) {
 	name := "invalid"
@@ -135,3 +156,142 @@ func TestParser_Parse_ReaderError(t *testing.T) {
 		t.Fatalf("err = %v, want boom", err)
 	}
 }
+
+// Extension objects decode into user structs the same way XML extensions do.
+func TestParser_Parse_ExtensionDecode(t *testing.T) {
+	f, err := os.ReadFile("../testdata/parser/json/json11_extensions_hubs.json")
+	assert.NoError(t, err)
+
+	feed, err := (&jsonParser.Parser{}).Parse(bytes.NewReader(f))
+	assert.NoError(t, err)
+
+	var podcast struct {
+		Explicit   bool     `feed:"explicit"`
+		Categories []string `feed:"categories"`
+		Owner      struct {
+			Name string `feed:"name"`
+		} `feed:"owner"`
+	}
+	assert.NoError(t, feed.Extensions.Decode("itunes", &podcast))
+	assert.False(t, podcast.Explicit)
+	assert.Equal(t, []string{"Technology", "News"}, podcast.Categories)
+	assert.Equal(t, "Jane", podcast.Owner.Name)
+
+	var episode struct {
+		Episode int `feed:"episode"`
+	}
+	assert.NoError(t, feed.Items[0].Extensions.Decode("itunes", &episode))
+	assert.Equal(t, 1, episode.Episode)
+	assert.Equal(t, "hello", feed.Extensions["note"]["note"][0].Value)
+}
+
+// Items are decoded as they stream in, with the same coercion as a buffered
+// document, and metadata after the items is still read.
+func TestParser_Parse_Streaming(t *testing.T) {
+	doc := `{"version": "https://jsonfeed.org/version/1.1", "items": [` +
+		`{"id": 1, "title": "first", "attachments": [{"url": "a.mp3", "size_in_bytes": 1.5e3}]},` +
+		`{"id": "two", "_ext": {"k": "v"}}` +
+		`], "title": "after", "expired": "true", "_meta": {"a": 1}}`
+
+	feed, err := (&jsonParser.Parser{}).Parse(strings.NewReader(doc))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "after", feed.Title)
+	assert.True(t, feed.Expired)
+	assert.Equal(t, "1", feed.Extensions["meta"]["a"][0].Value)
+	assert.Len(t, feed.Items, 2)
+	assert.Equal(t, "1", feed.Items[0].ID)
+	assert.Equal(t, int64(1500), (*feed.Items[0].Attachments)[0].SizeInBytes)
+	assert.Equal(t, "v", feed.Items[1].Extensions["ext"]["k"][0].Value)
+}
+
+// Walk hands over each item as it is decoded, without keeping them, and
+// still reads the members after the items.
+func TestParser_Walk(t *testing.T) {
+	doc := `{"version": "https://jsonfeed.org/version/1.1", "items": [` +
+		`{"id": 1, "title": "first"}, {"id": "two"}], "title": "after"}`
+
+	var ids []string
+	feed, err := (&jsonParser.Parser{}).Walk(strings.NewReader(doc), func(item *jsonParser.Item) error {
+		ids = append(ids, item.ID)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []string{"1", "two"}, ids)
+	assert.Equal(t, "after", feed.Title)
+	assert.Nil(t, feed.Items)
+
+	// An error from the callback stops the walk.
+	stop := errors.New("stop")
+	ids = nil
+	_, err = (&jsonParser.Parser{}).Walk(strings.NewReader(doc), func(item *jsonParser.Item) error {
+		ids = append(ids, item.ID)
+		return stop
+	})
+	assert.Equal(t, stop, err)
+	assert.Equal(t, []string{"1"}, ids)
+}
+
+func TestParser_Parse_ItemsShape(t *testing.T) {
+	tests := []struct {
+		doc   string
+		items []*jsonParser.Item
+		err   bool
+	}{
+		{`{"title": "t"}`, nil, false},
+		{`{"items": null}`, nil, false},
+		{`{"items": []}`, []*jsonParser.Item{}, false},
+		{`{"items": {"id": "1"}}`, nil, true},
+		{`{"items": [{"id": "1"}`, nil, true},
+		{`{"Items": [{"id": "1"}]}`, []*jsonParser.Item{{ID: "1"}}, false},
+		{"{\"items\": []}\n", []*jsonParser.Item{}, false},
+		{`{"items": []} {"items": []}`, nil, true},
+		{`{"items": []}}`, nil, true},
+		{`{"items": []} trailing`, nil, true},
+		{`["not", "a", "feed"]`, nil, true},
+		{``, nil, true},
+	}
+
+	for _, test := range tests {
+		feed, err := (&jsonParser.Parser{}).Parse(strings.NewReader(test.doc))
+		if test.err {
+			assert.Error(t, err, test.doc)
+			continue
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, test.items, feed.Items, test.doc)
+	}
+}
+
+// An item that fails to decode fails the feed before the items after it
+// are read.
+func TestParser_Parse_StopsAtBadItem(t *testing.T) {
+	boom := errors.New("boom")
+	r := io.MultiReader(
+		strings.NewReader(`{"items": [{"id": "1"}, {"tags": "not an array"}, `),
+		iotest.ErrReader(boom))
+
+	_, err := (&jsonParser.Parser{}).Parse(r)
+	assert.Error(t, err)
+	assert.False(t, errors.Is(err, boom))
+}
+
+// A member named "extensions" is ordinary JSON, which a feed may use for
+// anything; only "_"-prefixed members are extensions.
+func TestParser_Parse_ExtensionsMember(t *testing.T) {
+	doc := `{"version": "https://jsonfeed.org/version/1.1", "extensions": "hello", ` +
+		`"items": [{"id": "1", "extensions": 5, "_ext": {"k": "v"}}]}`
+
+	feed, err := (&jsonParser.Parser{}).Parse(strings.NewReader(doc))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Nil(t, feed.Extensions)
+	assert.Equal(t, "1", feed.Items[0].ID)
+	assert.Equal(t, "v", feed.Items[0].Extensions["ext"]["k"][0].Value)
+}
@@ -0,0 +1,107 @@
+package gofeed
+
+import (
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+
+	ext "github.com/mmcdole/gofeed/extensions"
+)
+
+// License is the licensing information for a Feed or Item,
+// normalized from creativeCommons:license, cc:license, Atom
+// rel="license" links, dcterms:license and URL-valued dc:rights.
+type License struct {
+	URLs []string `json:"urls,omitempty"`
+	// ID is the SPDX style identifier (e.g. "CC-BY-SA-4.0") of the first
+	// URL recognized as a Creative Commons license, or "" if none is.
+	ID string `json:"id,omitempty"`
+}
+
+var (
+	// ccLicenseRgx matches creativecommons.org license deeds, with an
+	// optional jurisdiction for ported licenses.
+	ccLicenseRgx = regexp.MustCompile(`^/licenses/(by|by-sa|by-nd|by-nc|by-nc-sa|by-nc-nd)/(\d+\.\d+)(?:/([a-z]{2}))?(?:/|$)`)
+	// ccPublicDomainRgx matches the CC0 dedication and the Public Domain Mark.
+	ccPublicDomainRgx = regexp.MustCompile(`^/publicdomain/(zero|mark)/(\d+\.\d+)(?:/|$)`)
+)
+
+// CreativeCommonsLicenseID returns the SPDX style identifier for a Creative
+// Commons license URL, such as "CC-BY-4.0" or "CC0-1.0", or "" when the URL
+// is not a recognized Creative Commons license.
+func CreativeCommonsLicenseID(licenseURL string) string {
+	u, err := url.Parse(strings.TrimSpace(licenseURL))
+	if err != nil {
+		return ""
+	}
+	host := strings.TrimPrefix(strings.ToLower(u.Host), "www.")
+	if host != "creativecommons.org" {
+		return ""
+	}
+	path := strings.ToLower(u.Path)
+
+	if m := ccLicenseRgx.FindStringSubmatch(path); m != nil {
+		id := "CC-" + strings.ToUpper(m[1]) + "-" + m[2]
+		if m[3] != "" {
+			id += "-" + strings.ToUpper(m[3])
+		}
+		return id
+	}
+	if m := ccPublicDomainRgx.FindStringSubmatch(path); m != nil {
+		if m[1] == "zero" {
+			return "CC0-" + m[2]
+		}
+		return "CC-PDM-" + m[2]
+	}
+	return ""
+}
+
+// translateLicense gathers license URLs from rel="license" link hrefs and
+// the creativeCommons, cc, dcterms and dc extensions. dc:rights is only used
+// when it holds a URL, since it is usually a free-text copyright statement.
+// It returns nil when no license is found.
+func translateLicense(links []string, exts ext.Extensions) *License {
+	urls := append([]string{}, links...)
+	for _, prefix := range []string{"creativeCommons", "cc"} {
+		if cc, ok := exts[prefix]; ok {
+			urls = append(urls, ext.NewCreativeCommonsExtension(cc).License...)
+		}
+	}
+	if dcterms, ok := ext.Get[*ext.DCTermsExtension](exts); ok {
+		urls = append(urls, dcterms.License...)
+	}
+	if dc, ok := exts["dc"]; ok {
+		for _, r := range dc["rights"] {
+			if isAbsoluteURL(r.Value) {
+				urls = append(urls, r.Value)
+			}
+		}
+	}
+
+	license := &License{}
+	for _, u := range urls {
+		u = strings.TrimSpace(u)
+		if u == "" || slices.Contains(license.URLs, u) {
+			continue
+		}
+		license.URLs = append(license.URLs, u)
+		if license.ID == "" {
+			license.ID = CreativeCommonsLicenseID(u)
+		}
+	}
+	if license.URLs == nil {
+		return nil
+	}
+	return license
+}
+
+// isAbsoluteURL reports whether s is a single absolute http(s) URL.
+func isAbsoluteURL(s string) bool {
+	s = strings.TrimSpace(s)
+	if strings.ContainsAny(s, " \t\r\n") {
+		return false
+	}
+	u, err := url.Parse(s)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
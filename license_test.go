@@ -0,0 +1,29 @@
+package gofeed_test
+
+import (
+	"testing"
+
+	"github.com/mmcdole/gofeed"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreativeCommonsLicenseID(t *testing.T) {
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{"http://creativecommons.org/licenses/by/4.0/", "CC-BY-4.0"},
+		{"https://creativecommons.org/licenses/by-sa/4.0/legalcode", "CC-BY-SA-4.0"},
+		{"https://www.creativecommons.org/licenses/by-nc-nd/2.5", "CC-BY-NC-ND-2.5"},
+		{"http://creativecommons.org/licenses/by/3.0/de/", "CC-BY-3.0-DE"},
+		{"https://creativecommons.org/publicdomain/zero/1.0/", "CC0-1.0"},
+		{"https://creativecommons.org/publicdomain/mark/1.0/", "CC-PDM-1.0"},
+		{"https://creativecommons.org/licenses/", ""},
+		{"https://example.org/licenses/by/4.0/", ""},
+		{"not a url", ""},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.want, gofeed.CreativeCommonsLicenseID(test.url), test.url)
+	}
+}
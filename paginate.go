@@ -0,0 +1,176 @@
+package gofeed
+
+import (
+	"context"
+	"errors"
+	"net/url"
+)
+
+// DefaultMaxPages is how many pages the paginating fetchers retrieve when
+// PageOptions.MaxPages is zero.
+const DefaultMaxPages = 50
+
+// ErrStopPaging can be returned by a WalkURLPagesWithContext callback to
+// stop following next links without reporting an error.
+var ErrStopPaging = errors.New("gofeed: stop paging")
+
+// PageOptions limits how far the paginating fetchers follow a feed's next
+// links (JSON Feed next_url, or an Atom rel="next" link in Atom and RSS).
+type PageOptions struct {
+	// MaxPages is the most documents fetched, counting the first. Zero
+	// means DefaultMaxPages.
+	MaxPages int
+	// MaxItems stops paging once this many items have been collected;
+	// items past the limit are dropped. The merging fetchers count each
+	// item once, however many pages repeat it. A page cut short by the
+	// limit is the link left to resume from, so that its remaining items
+	// are not skipped. Zero means no limit.
+	MaxItems int
+}
+
+// WalkURLPagesWithContext fetches feedURL and then each page its next link
+// points to, calling fn with every page in order. Pages are fetched with the
+// same HTTP settings as ParseURLWithContext, and relative next links are
+// resolved against the page they appear on.
+//
+// Walking stops when a page has no next link, when the next link points to a
+// page already fetched, when a limit in opts is reached, or when fn returns
+// an error. An error from fn is returned as is, except ErrStopPaging, which
+// stops the walk and returns nil.
+func (f *Parser) WalkURLPagesWithContext(feedURL string, ctx context.Context, opts PageOptions, fn func(page *Feed) error) error {
+	_, err := f.walkPages(feedURL, ctx, opts, nextPageURL, false, fn)
+	return err
+}
+
+// ParseURLPagesWithContext fetches feedURL and follows its next links like
+// WalkURLPagesWithContext, merging the pages into one Feed. The merged Feed
+// carries the first page's metadata and the items of every page in page
+// order; an item repeated on a later page (same GUID, or same link when it
+// has no GUID) is kept once. Its NextURL is the next link that was not
+// followed because a limit was reached, or the page MaxItems cut short, or
+// "" when the history was exhausted, so a later call can resume from it.
+func (f *Parser) ParseURLPagesWithContext(feedURL string, ctx context.Context, opts PageOptions) (*Feed, error) {
+	var merged *Feed
+
+	next, err := f.walkPages(feedURL, ctx, opts, nextPageURL, true, func(page *Feed) error {
+		items := page.Items
+		if merged == nil {
+			merged = page
+			merged.Items = nil
+		}
+		merged.Items = append(merged.Items, items...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	merged.NextURL = next
+	if merged.Items == nil {
+		merged.Items = []*Item{}
+	}
+	return merged, nil
+}
+
+// walkPages implements the paginating and archive fetchers, following the
+// link that link returns for each page. With unique set, an item already
+// seen on an earlier page is dropped from the page fn gets, before items
+// are counted towards opts.MaxItems. It returns the link that was left
+// unfollowed because of a limit, or the page cut short by MaxItems.
+func (f *Parser) walkPages(feedURL string, ctx context.Context, opts PageOptions, link func(page *Feed) string, unique bool, fn func(page *Feed) error) (string, error) {
+	maxPages := opts.MaxPages
+	if maxPages <= 0 {
+		maxPages = DefaultMaxPages
+	}
+
+	visited := map[string]bool{}
+	seen := map[string]bool{}
+	items := 0
+	pageURL := feedURL
+	for pages := 0; ; pages++ {
+		if pages == maxPages || (opts.MaxItems > 0 && items >= opts.MaxItems) {
+			return pageURL, nil
+		}
+		visited[pageKey(pageURL)] = true
+
+		page, err := f.ParseURLWithContext(pageURL, ctx)
+		if err != nil {
+			return "", err
+		}
+
+		kept := make([]*Item, 0, len(page.Items))
+		cut := false
+		for _, item := range page.Items {
+			key := itemKey(item)
+			if unique && key != "" && seen[key] {
+				continue
+			}
+			if opts.MaxItems > 0 && items == opts.MaxItems {
+				cut = true
+				break
+			}
+			seen[key] = true
+			kept = append(kept, item)
+			items++
+		}
+		page.Items = kept
+
+		err = fn(page)
+		if err != nil && !errors.Is(err, ErrStopPaging) {
+			return "", err
+		}
+		next := resolvePageURL(pageURL, link(page))
+		switch {
+		case cut:
+			return pageURL, nil
+		case err != nil:
+			return next, nil
+		case next == "" || visited[pageKey(next)]:
+			return "", nil
+		}
+		pageURL = next
+	}
+}
+
+// nextPageURL returns the link the paginating fetchers follow.
+func nextPageURL(page *Feed) string {
+	return page.NextURL
+}
+
+// resolvePageURL resolves a next link against the page it appeared on.
+func resolvePageURL(pageURL, next string) string {
+	if next == "" {
+		return ""
+	}
+	base, err := url.Parse(pageURL)
+	if err != nil {
+		return next
+	}
+	ref, err := url.Parse(next)
+	if err != nil {
+		return ""
+	}
+	return base.ResolveReference(ref).String()
+}
+
+// pageKey normalizes a page URL for cycle detection.
+func pageKey(pageURL string) string {
+	u, err := url.Parse(pageURL)
+	if err != nil {
+		return pageURL
+	}
+	u.Fragment = ""
+	return u.String()
+}
+
+// itemKey identifies an item across pages: its GUID, or its link when it
+// has none.
+func itemKey(item *Item) string {
+	if item.GUID != "" {
+		return "guid:" + item.GUID
+	}
+	if item.Link != "" {
+		return "link:" + item.Link
+	}
+	return ""
+}
@@ -0,0 +1,168 @@
+package gofeed_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mmcdole/gofeed"
+	"github.com/stretchr/testify/assert"
+)
+
+// pagedServer serves a three page JSON Feed whose last page links back to
+// the first, another of two pages, and a two page Atom feed.
+func pagedServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	jsonPage := func(n int, next string, ids ...string) {
+		mux.HandleFunc(fmt.Sprintf("/feed.json/%d", n), func(w http.ResponseWriter, r *http.Request) {
+			items := ""
+			for i, id := range ids {
+				if i > 0 {
+					items += ","
+				}
+				items += fmt.Sprintf(`{"id":%q,"content_text":"t"}`, id)
+			}
+			fmt.Fprintf(w, `{"version":"https://jsonfeed.org/version/1.1","title":"page %d","next_url":%q,"items":[%s]}`, n, next, items)
+		})
+	}
+	jsonPage(1, "2", "a", "b")
+	jsonPage(2, "/feed.json/3", "b", "c")
+	jsonPage(3, "/feed.json/1#again", "d")
+	jsonPage(11, "/feed.json/12", "e", "f")
+	jsonPage(12, "", "f", "g", "h")
+
+	mux.HandleFunc("/atom/1", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `<feed xmlns="http://www.w3.org/2005/Atom"><title>atom</title>
+			<link rel="next" href="/atom/2"/><entry><id>x</id></entry></feed>`)
+	})
+	mux.HandleFunc("/atom/2", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `<feed xmlns="http://www.w3.org/2005/Atom"><title>atom 2</title><entry><id>y</id></entry></feed>`)
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func itemIDs(feed *gofeed.Feed) (ids []string) {
+	for _, item := range feed.Items {
+		ids = append(ids, item.GUID)
+	}
+	return
+}
+
+func TestParser_ParseURLPages(t *testing.T) {
+	server := pagedServer(t)
+	fp := gofeed.NewParser()
+	ctx := context.Background()
+
+	// All pages, with the repeated item kept once and the cycle back to
+	// the first page stopping the walk.
+	feed, err := fp.ParseURLPagesWithContext(server.URL+"/feed.json/1", ctx, gofeed.PageOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, "page 1", feed.Title)
+	assert.Equal(t, []string{"a", "b", "c", "d"}, itemIDs(feed))
+	assert.Equal(t, "", feed.NextURL)
+
+	// A page limit leaves the unfollowed link to resume from.
+	feed, err = fp.ParseURLPagesWithContext(server.URL+"/feed.json/1", ctx, gofeed.PageOptions{MaxPages: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "b", "c"}, itemIDs(feed))
+	assert.Equal(t, server.URL+"/feed.json/3", feed.NextURL)
+
+	// An item limit counts each item once: page two adds only c to the a
+	// and b of page one.
+	feed, err = fp.ParseURLPagesWithContext(server.URL+"/feed.json/1", ctx, gofeed.PageOptions{MaxItems: 3})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "b", "c"}, itemIDs(feed))
+	assert.Equal(t, server.URL+"/feed.json/3", feed.NextURL)
+
+	// A page cut short by the limit is where to resume, so that its
+	// remaining items are not skipped.
+	feed, err = fp.ParseURLPagesWithContext(server.URL+"/feed.json/11", ctx, gofeed.PageOptions{MaxItems: 3})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"e", "f", "g"}, itemIDs(feed))
+	assert.Equal(t, server.URL+"/feed.json/12", feed.NextURL)
+
+	// Atom rel="next" links are followed too.
+	feed, err = fp.ParseURLPagesWithContext(server.URL+"/atom/1", ctx, gofeed.PageOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"x", "y"}, itemIDs(feed))
+
+	// Fetch errors are returned.
+	_, err = fp.ParseURLPagesWithContext(server.URL+"/missing", ctx, gofeed.PageOptions{})
+	var httpErr gofeed.HTTPError
+	assert.True(t, errors.As(err, &httpErr))
+}
+
+func TestParser_WalkURLPages(t *testing.T) {
+	server := pagedServer(t)
+	fp := gofeed.NewParser()
+	ctx := context.Background()
+
+	var titles []string
+	err := fp.WalkURLPagesWithContext(server.URL+"/feed.json/1", ctx, gofeed.PageOptions{}, func(page *gofeed.Feed) error {
+		titles = append(titles, page.Title)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"page 1", "page 2", "page 3"}, titles)
+
+	titles = nil
+	err = fp.WalkURLPagesWithContext(server.URL+"/feed.json/1", ctx, gofeed.PageOptions{}, func(page *gofeed.Feed) error {
+		titles = append(titles, page.Title)
+		return gofeed.ErrStopPaging
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"page 1"}, titles)
+
+	boom := errors.New("boom")
+	err = fp.WalkURLPagesWithContext(server.URL+"/feed.json/1", ctx, gofeed.PageOptions{}, func(page *gofeed.Feed) error {
+		return boom
+	})
+	assert.ErrorIs(t, err, boom)
+}
+
+// An ActivityPub outbox links its first page, and each page its next one;
+// paging starts at the outbox and follows both.
+func TestParser_ParseURLPages_ActivityStreamsOutbox(t *testing.T) {
+	page := func(n int, next string, ids ...string) string {
+		items := ""
+		for i, id := range ids {
+			if i > 0 {
+				items += ","
+			}
+			items += fmt.Sprintf(`{"id":"%s/activity","type":"Create","actor":"/jane","object":{"id":%q,"type":"Note","content":"c"}}`, id, id)
+		}
+		nextLink := ""
+		if next != "" {
+			nextLink = fmt.Sprintf(`"next":%q,`, next)
+		}
+		return fmt.Sprintf(`{"@context":"https://www.w3.org/ns/activitystreams","id":"/outbox?page=%d",
+			"type":"OrderedCollectionPage","partOf":"/outbox",%s"orderedItems":[%s]}`, n, nextLink, items)
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("page") {
+		case "1":
+			fmt.Fprint(w, page(1, "/outbox?page=2", "https://social.example/3", "https://social.example/2"))
+		case "2":
+			fmt.Fprint(w, page(2, "", "https://social.example/1"))
+		default:
+			fmt.Fprint(w, `{"@context":"https://www.w3.org/ns/activitystreams","id":"/outbox",
+				"type":"OrderedCollection","name":"outbox","totalItems":3,"first":"/outbox?page=1"}`)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	feed, err := gofeed.NewParser().ParseURLPagesWithContext(server.URL+"/outbox", context.Background(), gofeed.PageOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "activitystreams", feed.FeedType)
+	assert.Equal(t, "outbox", feed.Title)
+	assert.Equal(t, []string{"https://social.example/3", "https://social.example/2", "https://social.example/1"}, itemIDs(feed))
+	assert.Equal(t, "", feed.NextURL)
+}
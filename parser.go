@@ -11,8 +11,9 @@ import (
 	"strings"
 	"time"
 
+	"github.com/mmcdole/gofeed/activitystreams"
 	"github.com/mmcdole/gofeed/atom"
-	"github.com/mmcdole/gofeed/json"
+	"github.com/mmcdole/gofeed/hfeed"
 	"github.com/mmcdole/gofeed/rss"
 )
 
@@ -29,6 +30,11 @@ var ErrResponseTooLarge = errors.New("gofeed: response body exceeds MaxByteSize"
 // out the Feed format
 var ErrFeedTypeNotDetected = errors.New("failed to detect feed type")
 
+// ErrHTMLPage is returned when a document is an HTML page without an
+// h-feed, as servers return for a login or consent wall, a parked domain
+// or a site's home page. It wraps ErrFeedTypeNotDetected.
+var ErrHTMLPage = fmt.Errorf("%w: document is an HTML page, not a feed", ErrFeedTypeNotDetected)
+
 // HTTPError represents an HTTP error returned by a server.
 type HTTPError struct {
 	StatusCode int
@@ -44,12 +50,14 @@ func (err HTTPError) Error() string {
 // a given feed type, parsers it, and translates it
 // to the universal feed type.
 type Parser struct {
-	AtomTranslator Translator
-	RSSTranslator  Translator
-	JSONTranslator Translator
-	UserAgent      string
-	AuthConfig     *Auth
-	Client         *http.Client
+	AtomTranslator            Translator
+	RSSTranslator             Translator
+	JSONTranslator            Translator
+	HFeedTranslator           Translator
+	ActivityStreamsTranslator Translator
+	UserAgent                 string
+	AuthConfig                *Auth
+	Client                    *http.Client
 	// MaxByteSize limits how many bytes ParseURL/ParseURLWithContext will read
 	// from a response body. Zero means no limit. Exceeding it returns
 	// ErrResponseTooLarge rather than silently truncating.
@@ -58,9 +66,20 @@ type Parser struct {
 	// accessible via Feed.OriginalFeed(). Off by default: keeping it holds a
 	// second copy of the feed in memory for the lifetime of the result.
 	KeepOriginalFeed bool
-	rp               *rss.Parser
-	ap               *atom.Parser
-	jp               *json.Parser
+	// PreserveExtensionXML keeps the raw inner XML and the document order
+	// of content for RSS and Atom extension elements; see
+	// rss.Parser.PreserveExtensionXML.
+	PreserveExtensionXML bool
+	// PreserveTimeZone keeps the offset each date is written with in the
+	// parsed times of the result, for showing them in the publisher's
+	// local time. Off by default, when every parsed time is normalized to
+	// UTC whatever the format; see Feed.UpdatedParsed. It is passed on to
+	// the format parsers, so their own parsed times follow suit.
+	PreserveTimeZone bool
+	// IgnoreContentType makes ParseURL and ParseURLWithContext detect the
+	// feed type from the document alone, disregarding the response's
+	// Content-Type, for servers that mislabel their content.
+	IgnoreContentType bool
 }
 
 // Auth is a structure allowing to
@@ -75,18 +94,17 @@ type Auth struct {
 // default translators are stateless and http.Client is safe for concurrent
 // use, so single shared instances are fine and avoid per-parse allocation.
 var (
-	defaultAtomTranslator = &DefaultAtomTranslator{}
-	defaultRSSTranslator  = &DefaultRSSTranslator{}
-	defaultJSONTranslator = &DefaultJSONTranslator{}
-	defaultClient         = &http.Client{}
+	defaultAtomTranslator            = &DefaultAtomTranslator{}
+	defaultRSSTranslator             = &DefaultRSSTranslator{}
+	defaultJSONTranslator            = &DefaultJSONTranslator{}
+	defaultHFeedTranslator           = &DefaultHFeedTranslator{}
+	defaultActivityStreamsTranslator = &DefaultActivityStreamsTranslator{}
+	defaultClient                    = &http.Client{}
 )
 
 // NewParser creates a universal feed parser.
 func NewParser() *Parser {
 	fp := Parser{
-		rp:        &rss.Parser{},
-		ap:        &atom.Parser{},
-		jp:        &json.Parser{},
 		UserAgent: "Gofeed/1.0",
 	}
 	return &fp
@@ -99,14 +117,34 @@ func NewParser() *Parser {
 // root element starts beyond this window is not detected.
 const detectionPeekSize = 4096
 
-// Parse parses a RSS or Atom or JSON feed into
-// the universal gofeed.Feed.  It takes an
-// io.Reader which should return the xml/json content.
+// maxHTMLPageSize bounds the HTML pages Parse searches for an h-feed past
+// the detection window. A larger page, which has shown no h-feed or h-entry
+// class in its first few KB, is taken to be a plain page rather than read
+// into memory and parsed whole.
+const maxHTMLPageSize = 2 << 20
+
+// Parse parses a RSS or Atom or JSON feed, an ActivityStreams collection,
+// or an HTML page marked up with h-feed, into the universal gofeed.Feed.
+// It takes an io.Reader which should return the xml/json/html content.
 //
-// Only the first few KB are buffered to detect the feed type; RSS and Atom
-// content is then parsed incrementally from the reader. JSON feeds are read
-// fully into memory, as JSON decoding needs the complete document.
+// Only the first few KB are buffered to detect the feed type; RSS, Atom and
+// JSON Feed content is then parsed incrementally from the reader, though
+// the resulting Feed holds every item. ActivityStreams collections and HTML
+// pages are read fully into memory, as decoding them needs the complete
+// document. An HTML page whose h-feed classes only appear past the first
+// few KB is searched for them up to 2 MiB; a larger one returns
+// ErrHTMLPage.
 func (f *Parser) Parse(feed io.Reader) (*Feed, error) {
+	return f.ParseWithContentType(feed, "")
+}
+
+// ParseWithContentType is Parse for a document served with the given
+// Content-Type, as ParseURL uses the response's. The media types of the
+// registered formats (see Format.MediaTypes) settle which format to use
+// when the document alone leaves it open; sniffing still wins over a
+// Content-Type the document contradicts. A document served as text/html
+// without an h-feed returns ErrHTMLPage. An empty contentType is no hint.
+func (f *Parser) ParseWithContentType(feed io.Reader, contentType string) (*Feed, error) {
 	// Peek at the start of the stream to detect the feed type, without
 	// consuming it: the format parser below reads from the beginning. A
 	// reader error here surfaces as itself rather than as a failed type
@@ -117,13 +155,38 @@ func (f *Parser) Parse(feed io.Reader) (*Feed, error) {
 		return nil, err
 	}
 
-	switch DetectFeedType(bytes.NewReader(prefix)) {
-	case FeedTypeAtom:
-		return f.parseAtomFeed(br)
-	case FeedTypeRSS:
-		return f.parseRSSFeed(br)
-	case FeedTypeJSON:
-		return f.parseJSONFeed(br)
+	labeled := labeledFormat(contentType)
+	if labeled != nil && labeled.Detect(prefix) {
+		return f.parseFormat(labeled, br)
+	}
+	if format := detectFormat(prefix); format != nil {
+		return f.parseFormat(format, br)
+	}
+
+	// The h-feed classes of an HTML page may only appear past the detection
+	// window, so an HTML page gets a full look before giving up, as long as
+	// it is no larger than maxHTMLPageSize. Only a page that has the classes
+	// somewhere is parsed.
+	hfeedFormat := lookupFormat("hfeed")
+	if htmlPattern.Match(prefix) || (labeled != nil && labeled == hfeedFormat) {
+		if hfeedFormat == nil {
+			return nil, ErrHTMLPage
+		}
+		page, err := io.ReadAll(io.LimitReader(br, maxHTMLPageSize+1))
+		if err != nil {
+			return nil, err
+		}
+		if len(page) > maxHTMLPageSize || !hfeedPattern.Match(page) {
+			return nil, ErrHTMLPage
+		}
+		return f.parseFormat(hfeedFormat, bytes.NewReader(page))
+	}
+
+	// A document labeled as a feed is tried in that format when it does not
+	// look like something else, such as the error object of an API.
+	if labeled != nil && contentHint(prefix) == ContentUnknown &&
+		(labeled.Labeled == nil || labeled.Labeled(prefix)) {
+		return f.parseFormat(labeled, br)
 	}
 
 	return nil, ErrFeedTypeNotDetected
@@ -182,7 +245,11 @@ func (f *Parser) ParseURLWithContext(feedURL string, ctx context.Context) (feed
 	if f.MaxByteSize > 0 {
 		body = &limitedReader{r: resp.Body, left: f.MaxByteSize}
 	}
-	return f.Parse(body)
+	var contentType string
+	if !f.IgnoreContentType {
+		contentType = resp.Header.Get("Content-Type")
+	}
+	return f.ParseWithContentType(body, contentType)
 }
 
 // limitedReader returns ErrResponseTooLarge once more than the configured
@@ -208,70 +275,94 @@ func (f *Parser) ParseString(feed string) (*Feed, error) {
 	return f.Parse(strings.NewReader(feed))
 }
 
-func (f *Parser) parseAtomFeed(feed io.Reader) (*Feed, error) {
-	af, err := f.ap.Parse(feed)
-	if err != nil {
-		return nil, err
+// keepOriginal stashes the source feed on the result when KeepOriginalFeed is
+// set. Gating here keeps the Translator interface free of parse options.
+func (f *Parser) keepOriginal(result *Feed, original interface{}) {
+	if f.KeepOriginalFeed && result != nil {
+		result.originalFeed = original
 	}
-	result, err := f.atomTrans().Translate(af)
-	f.keepOriginal(result, af)
-	return result, err
 }
 
-func (f *Parser) parseRSSFeed(feed io.Reader) (*Feed, error) {
-	rf, err := f.rp.Parse(feed)
-	if err != nil {
-		return nil, err
+// The format parsers hold nothing but their options, so one of each per
+// combination of the Parser options they take is built once and shared,
+// indexed by parserOptions.
+var (
+	rssParsers = [...]*rss.Parser{
+		{},
+		{PreserveExtensionXML: true},
+		{PreserveTimeZone: true},
+		{PreserveExtensionXML: true, PreserveTimeZone: true},
 	}
+	atomParsers = [...]*atom.Parser{
+		{},
+		{PreserveExtensionXML: true},
+		{PreserveTimeZone: true},
+		{PreserveExtensionXML: true, PreserveTimeZone: true},
+	}
+	hfeedParsers           = [...]*hfeed.Parser{{}, {PreserveTimeZone: true}}
+	activityStreamsParsers = [...]*activitystreams.Parser{{}, {PreserveTimeZone: true}}
+)
 
-	result, err := f.rssTrans().Translate(rf)
-	f.keepOriginal(result, rf)
-	return result, err
-}
-
-func (f *Parser) parseJSONFeed(feed io.Reader) (*Feed, error) {
-	jf, err := f.jp.Parse(feed)
-	if err != nil {
-		return nil, err
+// parserOptions indexes the shared format parsers by the Parser options.
+func (f *Parser) parserOptions() int {
+	options := 0
+	if f.PreserveExtensionXML {
+		options |= 1
+	}
+	if f.PreserveTimeZone {
+		options |= 2
 	}
-	result, err := f.jsonTrans().Translate(jf)
-	f.keepOriginal(result, jf)
-	return result, err
+	return options
 }
 
-// keepOriginal stashes the source feed on the result when KeepOriginalFeed is
-// set. Gating here keeps the Translator interface free of parse options.
-func (f *Parser) keepOriginal(result *Feed, original interface{}) {
-	if f.KeepOriginalFeed && result != nil {
-		result.originalFeed = original
-	}
+// rssParser returns the RSS parser, configured per the Parser options.
+func (f *Parser) rssParser() *rss.Parser {
+	return rssParsers[f.parserOptions()]
 }
 
-// These accessors return a shared default when the corresponding field is
-// unset. They must not write back to the Parser: doing so races when one Parser
-// is shared across goroutines (a common pattern for crawlers).
+// atomParser returns the Atom parser, configured per the Parser options.
+func (f *Parser) atomParser() *atom.Parser {
+	return atomParsers[f.parserOptions()]
+}
 
-func (f *Parser) atomTrans() Translator {
-	if f.AtomTranslator != nil {
-		return f.AtomTranslator
-	}
-	return defaultAtomTranslator
+// hfeedParser returns the h-feed parser, configured per the Parser options.
+func (f *Parser) hfeedParser() *hfeed.Parser {
+	return hfeedParsers[f.parserOptions()>>1]
 }
 
-func (f *Parser) rssTrans() Translator {
-	if f.RSSTranslator != nil {
-		return f.RSSTranslator
-	}
-	return defaultRSSTranslator
+// activityStreamsParser returns the ActivityStreams parser, configured per
+// the Parser options.
+func (f *Parser) activityStreamsParser() *activitystreams.Parser {
+	return activityStreamsParsers[f.parserOptions()>>1]
 }
 
-func (f *Parser) jsonTrans() Translator {
-	if f.JSONTranslator != nil {
-		return f.JSONTranslator
+// normalizeTimes brings the parsed times of a result to UTC, unless
+// PreserveTimeZone is set. Translators keep whatever offset they are given
+// or parse, so without this the formats would disagree. Each time gets a
+// new pointer, as it may be shared with the original feed.
+func (f *Parser) normalizeTimes(result *Feed) {
+	if f.PreserveTimeZone || result == nil {
+		return
+	}
+	times := []**time.Time{&result.UpdatedParsed, &result.PublishedParsed}
+	if result.UpdateSchedule != nil {
+		times = append(times, &result.UpdateSchedule.Base)
+	}
+	for _, item := range result.Items {
+		times = append(times, &item.UpdatedParsed, &item.PublishedParsed)
+	}
+	for _, t := range times {
+		if *t != nil {
+			utc := (*t).UTC()
+			*t = &utc
+		}
 	}
-	return defaultJSONTranslator
 }
 
+// httpClient returns a shared default when Client is unset. Like the
+// translator defaults in parseFormat, it must not write back to the Parser:
+// doing so races when one Parser is shared across goroutines (a common
+// pattern for crawlers).
 func (f *Parser) httpClient() *http.Client {
 	if f.Client != nil {
 		return f.Client
@@ -17,6 +17,7 @@ import (
 	"time"
 
 	"github.com/mmcdole/gofeed"
+	ext "github.com/mmcdole/gofeed/extensions"
 	"github.com/mmcdole/gofeed/rss"
 	"github.com/stretchr/testify/assert"
 )
@@ -37,9 +38,13 @@ func TestParser_Parse(t *testing.T) {
 		{"sample.json", "json", "title", false},
 		{"json10_feed.json", "json", "title", false},
 		{"json11_feed.json", "json", "title", false},
+		{"hfeed.html", "hfeed", "Feed Title", false},
+		{"activitystreams_outbox.json", "activitystreams", "Feed Title", false},
 		{"unknown_feed.xml", "", "", true},
+		{"html_page.html", "", "", true},
 		{"empty_feed.xml", "", "", true},
 		{"invalid.json", "", "", true},
+		{"api_error.json", "", "", true},
 	}
 
 	for _, test := range feedTests {
@@ -82,6 +87,7 @@ func TestParser_ParseString(t *testing.T) {
 		{"unknown_feed.xml", "", "", true},
 		{"empty_feed.xml", "", "", true},
 		{"invalid.json", "", "", true},
+		{"api_error.json", "", "", true},
 	}
 
 	for _, test := range feedTests {
@@ -124,6 +130,7 @@ func TestParser_ParseURL_Success(t *testing.T) {
 		{"json11_feed.json", "json", "title", false},
 		{"unknown_feed.xml", "", "", true},
 		{"invalid.json", "", "", true},
+		{"api_error.json", "", "", true},
 	}
 
 	for _, test := range feedTests {
@@ -400,6 +407,97 @@ func TestParserKeepOriginalFeed(t *testing.T) {
 	}
 }
 
+func TestParserPreserveExtensionXML(t *testing.T) {
+	const feed = `<feed xmlns="http://www.w3.org/2005/Atom" xmlns:ex="http://example.com/ns" xmlns:xhtml="http://www.w3.org/1999/xhtml">
+	<entry><title>e</title>
+		<ex:note>Read <ex:em>this</ex:em><!-- editor's note --> first &amp; last</ex:note>
+		<xhtml:body><xhtml:p>Hi <xhtml:b>there</xhtml:b></xhtml:p></xhtml:body>
+	</entry></feed>`
+
+	// Off by default.
+	p := gofeed.NewParser()
+	f, err := p.ParseString(feed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	note := f.Items[0].Extensions["ex"]["note"][0]
+	if note.InnerXML != "" || note.Content != nil {
+		t.Errorf("InnerXML/Content set without PreserveExtensionXML: %q, %v", note.InnerXML, note.Content)
+	}
+
+	p.PreserveExtensionXML = true
+	f, err = p.ParseString(feed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	note = f.Items[0].Extensions["ex"]["note"][0]
+	if want := "Read <ex:em>this</ex:em><!-- editor's note --> first &amp; last"; note.InnerXML != want {
+		t.Errorf("InnerXML = %q, want %q", note.InnerXML, want)
+	}
+	// The usual fields are unchanged.
+	if note.Value != "Read  first & last" || note.Children["em"][0].Value != "this" {
+		t.Errorf("Value = %q, em = %v", note.Value, note.Children["em"])
+	}
+	if note.Children["em"][0].Namespace != "http://example.com/ns" {
+		t.Errorf("child namespace = %q", note.Children["em"][0].Namespace)
+	}
+
+	want := []ext.Node{
+		{Type: ext.TextNode, Text: "Read "},
+		{Type: ext.ElementNode, Element: &note.Children["em"][0]},
+		{Type: ext.CommentNode, Text: " editor's note "},
+		{Type: ext.TextNode, Text: " first & last"},
+	}
+	if len(note.Content) != len(want) {
+		t.Fatalf("Content = %+v, want %d nodes", note.Content, len(want))
+	}
+	for i, n := range note.Content {
+		if n.Type != want[i].Type || n.Text != want[i].Text {
+			t.Errorf("Content[%d] = %+v, want %+v", i, n, want[i])
+		}
+		if n.Type == ext.ElementNode && (n.Element == nil || n.Element.Value != "this") {
+			t.Errorf("Content[%d].Element = %+v", i, n.Element)
+		}
+	}
+
+	// Embedded XHTML can be reprocessed from its markup.
+	body := f.Items[0].Extensions["xhtml"]["body"][0]
+	if want := "<xhtml:p>Hi <xhtml:b>there</xhtml:b></xhtml:p>"; body.InnerXML != want {
+		t.Errorf("xhtml:body InnerXML = %q, want %q", body.InnerXML, want)
+	}
+	// Nested elements keep their own inner XML.
+	para := body.Children["p"][0]
+	if want := "Hi <xhtml:b>there</xhtml:b>"; para.InnerXML != want {
+		t.Errorf("xhtml:p InnerXML = %q, want %q", para.InnerXML, want)
+	}
+	if want := "there"; para.Children["b"][0].InnerXML != want {
+		t.Errorf("xhtml:b InnerXML = %q, want %q", para.Children["b"][0].InnerXML, want)
+	}
+}
+
+// A deeply nested extension is parsed in one pass, each element keeping the
+// inner XML as written.
+func TestParserPreserveExtensionXML_Deep(t *testing.T) {
+	const depth = 2000
+	inner := strings.Repeat("<ex:n>", depth) + "leaf" + strings.Repeat("</ex:n>", depth)
+	feed := `<rss version="2.0" xmlns:ex="http://example.com/ns"><channel><item>` +
+		`<ex:root>` + inner + `</ex:root></item></channel></rss>`
+
+	p := gofeed.NewParser()
+	p.PreserveExtensionXML = true
+	f, err := p.ParseString(feed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	e := f.Items[0].Extensions["ex"]["root"][0]
+	assert.Equal(t, inner, e.InnerXML)
+	for i := 0; i < depth; i++ {
+		e = e.Children["n"][0]
+	}
+	assert.Equal(t, "leaf", e.InnerXML)
+	assert.Equal(t, "leaf", e.Value)
+}
+
 // An I/O error from the reader must surface as itself, not be masked as a
 // failed type detection (issue #311).
 func TestParser_Parse_ReaderError(t *testing.T) {
@@ -433,3 +531,174 @@ func TestParser_Parse_RootBeyondDetectionWindow(t *testing.T) {
 	_, err := gofeed.NewParser().Parse(strings.NewReader(pad + `<rss version="2.0"><channel></channel></rss>`))
 	assert.ErrorIs(t, err, gofeed.ErrFeedTypeNotDetected)
 }
+
+// The h-feed of an HTML page whose markup before it outgrows the detection
+// window is still found.
+func TestParser_Parse_HFeedBeyondDetectionWindow(t *testing.T) {
+	page := `<!DOCTYPE html><html><head><title>Page</title></head><body>` +
+		`<p>` + strings.Repeat("x", 8192) + `</p>` +
+		`<div class="h-entry"><p class="p-name">Late Entry</p></div></body></html>`
+	feed, err := gofeed.NewParser().Parse(strings.NewReader(page))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "hfeed", feed.FeedType)
+	assert.Equal(t, "Page", feed.Title)
+	assert.Equal(t, "Late Entry", feed.Items[0].Title)
+}
+
+// The search for an h-feed past the detection window is bounded: a page
+// without one is not parsed, and neither is a page too large to search.
+func TestParser_Parse_HTMLPageBeyondDetectionWindow(t *testing.T) {
+	head := `<!DOCTYPE html><html><head><title>Page</title></head><body>`
+	entry := `<div class="h-entry"><p class="p-name">Late Entry</p></div></body></html>`
+
+	_, err := gofeed.NewParser().Parse(strings.NewReader(head + `<p>` + strings.Repeat("x", 8192) + `</p></body></html>`))
+	assert.ErrorIs(t, err, gofeed.ErrHTMLPage)
+
+	_, err = gofeed.NewParser().Parse(strings.NewReader(head + `<p>` + strings.Repeat("x", 3<<20) + `</p>` + entry))
+	assert.ErrorIs(t, err, gofeed.ErrHTMLPage)
+}
+
+// A page detected as an h-feed whose classes turn out to hold no h-feed or
+// h-entry, here a code sample, is an HTML page like any other.
+func TestParser_Parse_HTMLPageWithoutHFeed(t *testing.T) {
+	page := `<!DOCTYPE html><html><head><title>Docs</title></head><body>` +
+		`<pre><code>&lt;article class="h-entry"&gt;</code></pre></body></html>`
+	_, err := gofeed.NewParser().Parse(strings.NewReader(page))
+	assert.ErrorIs(t, err, gofeed.ErrHTMLPage)
+
+	srv := contentTypeServer("text/html", page)
+	defer srv.Close()
+	_, err = gofeed.NewParser().ParseURL(srv.URL)
+	assert.ErrorIs(t, err, gofeed.ErrHTMLPage)
+}
+
+// contentTypeServer serves body labeled with contentType.
+func contentTypeServer(contentType, body string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", contentType)
+		io.WriteString(w, body)
+	}))
+}
+
+func TestParser_ParseURL_ContentTypeHint(t *testing.T) {
+	pad := "<!-- " + strings.Repeat("x", 8192) + " -->"
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		feedType    string
+		err         error
+	}{
+		{"rss beyond detection window", "application/rss+xml; charset=utf-8",
+			pad + `<rss version="2.0"><channel><title>Late</title></channel></rss>`, "rss", nil},
+		{"atom beyond detection window", "application/atom+xml",
+			pad + `<feed xmlns="http://www.w3.org/2005/Atom"><title>Late</title></feed>`, "atom", nil},
+		{"json feed without version", "application/feed+json",
+			`{"title": "Late", "items": [{"id": "1"}]}`, "json", nil},
+		{"json feed served as json", "application/json",
+			`{"title": "Late", "items": [{"id": "1"}]}`, "json", nil},
+		{"json api error", "application/json",
+			`{"error": "rate limited"}`, "", gofeed.ErrFeedTypeNotDetected},
+		{"json without feed members", "application/json",
+			`{"title": "Not a feed"}`, "", gofeed.ErrFeedTypeNotDetected},
+		{"mislabeled rss", "text/html",
+			`<rss version="2.0"><channel><title>Late</title></channel></rss>`, "rss", nil},
+		{"login wall", "application/rss+xml",
+			`<!DOCTYPE html><html><head><title>Sign in</title></head><body><form></form></body></html>`, "", gofeed.ErrHTMLPage},
+		{"html fragment", "text/html; charset=utf-8",
+			`<div><p>Please accept cookies</p></div>`, "", gofeed.ErrHTMLPage},
+		{"html fragment with h-entry", "text/html",
+			`<div class="h-entry"><p class="p-name">Late</p></div>`, "hfeed", nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			srv := contentTypeServer(test.contentType, test.body)
+			defer srv.Close()
+
+			feed, err := gofeed.NewParser().ParseURL(srv.URL)
+			if test.err != nil {
+				assert.ErrorIs(t, err, test.err)
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, test.feedType, feed.FeedType)
+		})
+	}
+}
+
+func TestParser_ParseURL_IgnoreContentType(t *testing.T) {
+	srv := contentTypeServer("application/feed+json", `{"title": "Late", "items": []}`)
+	defer srv.Close()
+
+	p := gofeed.NewParser()
+	if _, err := p.ParseURL(srv.URL); err != nil {
+		t.Fatal(err)
+	}
+	p.IgnoreContentType = true
+	_, err := p.ParseURL(srv.URL)
+	assert.ErrorIs(t, err, gofeed.ErrFeedTypeNotDetected)
+}
+
+func TestParser_Parse_HTMLPage(t *testing.T) {
+	_, err := gofeed.NewParser().ParseString(`<!DOCTYPE html><html><head><title>Home</title></head><body></body></html>`)
+	assert.ErrorIs(t, err, gofeed.ErrHTMLPage)
+	assert.ErrorIs(t, err, gofeed.ErrFeedTypeNotDetected)
+}
+
+// Every format gives the same parsed times: UTC by default, and with the
+// offset the date is written with under PreserveTimeZone.
+func TestParser_PreserveTimeZone(t *testing.T) {
+	docs := map[string]string{
+		"rss": `<rss version="2.0"><channel><item><title>a</title>` +
+			`<pubDate>Sat, 05 Oct 2024 14:30:00 +0200</pubDate></item></channel></rss>`,
+		"atom": `<feed xmlns="http://www.w3.org/2005/Atom"><entry><title>a</title>` +
+			`<published>2024-10-05T14:30:00+02:00</published></entry></feed>`,
+		"json": `{"version": "https://jsonfeed.org/version/1.1", "items": [` +
+			`{"id": "a", "date_published": "2024-10-05T14:30:00+02:00"}]}`,
+		"hfeed": `<!DOCTYPE html><html><body><div class="h-entry"><p class="p-name">a</p>` +
+			`<time class="dt-published" datetime="2024-10-05T14:30:00+02:00"></time></div></body></html>`,
+		"activitystreams": `{"@context": "https://www.w3.org/ns/activitystreams", "type": "OrderedCollection", ` +
+			`"orderedItems": [{"type": "Create", "object": {"type": "Note", "content": "a", ` +
+			`"published": "2024-10-05T14:30:00+02:00"}}]}`,
+	}
+	want := time.Date(2024, 10, 5, 12, 30, 0, 0, time.UTC)
+
+	for feedType, doc := range docs {
+		t.Run(feedType, func(t *testing.T) {
+			fp := gofeed.NewParser()
+			feed, err := fp.ParseString(doc)
+			if err != nil {
+				t.Fatal(err)
+			}
+			published := feed.Items[0].PublishedParsed
+			assert.Equal(t, want, *published)
+			assert.Equal(t, time.UTC, published.Location())
+
+			fp.PreserveTimeZone = true
+			if feed, err = fp.ParseString(doc); err != nil {
+				t.Fatal(err)
+			}
+			published = feed.Items[0].PublishedParsed
+			assert.True(t, want.Equal(*published))
+			_, offset := published.Zone()
+			assert.Equal(t, 2*60*60, offset)
+			assert.Equal(t, 14, published.Hour())
+		})
+	}
+}
+
+// A JSON Feed member named "extensions" is not taken for extension objects.
+func TestParser_Parse_JSONExtensionsMember(t *testing.T) {
+	feed, err := gofeed.NewParser().ParseString(`{"version": "https://jsonfeed.org/version/1.1", ` +
+		`"extensions": "hello", "items": [{"id": "1", "extensions": 5}]}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "1", feed.Items[0].GUID)
+	assert.Nil(t, feed.Items[0].Extensions)
+}
@@ -9,33 +9,35 @@ import (
 
 // Feed is an RSS Feed
 type Feed struct {
-	Title               string                   `json:"title,omitempty"`
-	Link                string                   `json:"link,omitempty"`
-	Links               []string                 `json:"links,omitempty"`
-	Description         string                   `json:"description,omitempty"`
-	Language            string                   `json:"language,omitempty"`
-	Copyright           string                   `json:"copyright,omitempty"`
-	ManagingEditor      string                   `json:"managingEditor,omitempty"`
-	WebMaster           string                   `json:"webMaster,omitempty"`
-	PubDate             string                   `json:"pubDate,omitempty"`
-	PubDateParsed       *time.Time               `json:"pubDateParsed,omitempty"`
-	LastBuildDate       string                   `json:"lastBuildDate,omitempty"`
-	LastBuildDateParsed *time.Time               `json:"lastBuildDateParsed,omitempty"`
-	Categories          []*Category              `json:"categories,omitempty"`
-	Generator           string                   `json:"generator,omitempty"`
-	Docs                string                   `json:"docs,omitempty"`
-	TTL                 string                   `json:"ttl,omitempty"`
-	Image               *Image                   `json:"image,omitempty"`
-	Rating              string                   `json:"rating,omitempty"`
-	SkipHours           []string                 `json:"skipHours,omitempty"`
-	SkipDays            []string                 `json:"skipDays,omitempty"`
-	Cloud               *Cloud                   `json:"cloud,omitempty"`
-	TextInput           *TextInput               `json:"textInput,omitempty"`
-	DublinCoreExt       *ext.DublinCoreExtension `json:"dcExt,omitempty"`
-	ITunesExt           *ext.ITunesFeedExtension `json:"itunesExt,omitempty"`
-	Extensions          ext.Extensions           `json:"extensions,omitempty"`
-	Items               []*Item                  `json:"items"`
-	Version             string                   `json:"version"`
+	Title               string                    `json:"title,omitempty"`
+	Link                string                    `json:"link,omitempty"`
+	Links               []string                  `json:"links,omitempty"`
+	Description         string                    `json:"description,omitempty"`
+	Language            string                    `json:"language,omitempty"`
+	Copyright           string                    `json:"copyright,omitempty"`
+	ManagingEditor      string                    `json:"managingEditor,omitempty"`
+	WebMaster           string                    `json:"webMaster,omitempty"`
+	PubDate             string                    `json:"pubDate,omitempty"`
+	PubDateParsed       *time.Time                `json:"pubDateParsed,omitempty"`
+	LastBuildDate       string                    `json:"lastBuildDate,omitempty"`
+	LastBuildDateParsed *time.Time                `json:"lastBuildDateParsed,omitempty"`
+	Categories          []*Category               `json:"categories,omitempty"`
+	Generator           string                    `json:"generator,omitempty"`
+	Docs                string                    `json:"docs,omitempty"`
+	TTL                 string                    `json:"ttl,omitempty"`
+	Image               *Image                    `json:"image,omitempty"`
+	Rating              string                    `json:"rating,omitempty"`
+	SkipHours           []string                  `json:"skipHours,omitempty"`
+	SkipDays            []string                  `json:"skipDays,omitempty"`
+	Cloud               *Cloud                    `json:"cloud,omitempty"`
+	TextInput           *TextInput                `json:"textInput,omitempty"`
+	DublinCoreExt       *ext.DublinCoreExtension  `json:"dcExt,omitempty"`
+	DCTermsExt          *ext.DCTermsExtension     `json:"dctermsExt,omitempty"`
+	ITunesExt           *ext.ITunesFeedExtension  `json:"itunesExt,omitempty"`
+	SyndicationExt      *ext.SyndicationExtension `json:"syExt,omitempty"`
+	Extensions          ext.Extensions            `json:"extensions,omitempty"`
+	Items               []*Item                   `json:"items"`
+	Version             string                    `json:"version"`
 }
 
 func (f Feed) String() string {
@@ -60,6 +62,7 @@ type Item struct {
 	PubDateParsed *time.Time               `json:"pubDateParsed,omitempty"`
 	Source        *Source                  `json:"source,omitempty"`
 	DublinCoreExt *ext.DublinCoreExtension `json:"dcExt,omitempty"`
+	DCTermsExt    *ext.DCTermsExtension    `json:"dctermsExt,omitempty"`
 	ITunesExt     *ext.ITunesItemExtension `json:"itunesExt,omitempty"`
 	Extensions    ext.Extensions           `json:"extensions,omitempty"`
 	Custom        map[string]string        `json:"custom,omitempty"`
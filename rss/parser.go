@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"io"
 	"strings"
-	"time"
 
 	ext "github.com/mmcdole/gofeed/extensions"
 	"github.com/mmcdole/gofeed/internal/shared"
@@ -12,7 +11,17 @@ import (
 )
 
 // Parser is a RSS Parser
-type Parser struct{}
+type Parser struct {
+	// PreserveExtensionXML keeps the raw inner XML of every extension
+	// element and its text, comments and children in document order
+	// (ext.Extension InnerXML and Content). Off by default, as it roughly
+	// doubles the memory held by extensions.
+	PreserveExtensionXML bool
+	// PreserveTimeZone keeps the offset dates are written with in
+	// PubDateParsed and LastBuildDateParsed, which are otherwise
+	// normalized to UTC.
+	PreserveTimeZone bool
+}
 
 // Parse parses an xml feed into an rss.Feed
 func (rp *Parser) Parse(feed io.Reader) (*Feed, error) {
@@ -108,20 +117,9 @@ func (rp *Parser) parseChannel(p *xpp.Parser) (rss *Feed, err error) {
 	categories := []*Category{}
 	links := []string{}
 
-	// parseDate mirrors the historical behavior for pubDate and
-	// lastBuildDate: the raw text is kept even when unparseable, and the
-	// parsed form is normalized to UTC.
-	parseDate := func(text string) *time.Time {
-		if date, err := shared.ParseDate(text); err == nil {
-			utc := date.UTC()
-			return &utc
-		}
-		return nil
-	}
-
 	err = shared.ForEachChild(p, func(name string) error {
 		if shared.IsExtension(p) {
-			extensions, err = shared.ParseExtension(extensions, p)
+			extensions, err = shared.ParseExtension(extensions, p, rp.PreserveExtensionXML)
 			return err
 		}
 		var err error
@@ -144,11 +142,11 @@ func (rp *Parser) parseChannel(p *xpp.Parser) (rss *Feed, err error) {
 			rss.WebMaster, err = shared.ParseText(p)
 		case "pubdate":
 			if rss.PubDate, err = shared.ParseText(p); err == nil {
-				rss.PubDateParsed = parseDate(rss.PubDate)
+				rss.PubDateParsed = shared.ParseDateTime(rss.PubDate, rp.PreserveTimeZone)
 			}
 		case "lastbuilddate":
 			if rss.LastBuildDate, err = shared.ParseText(p); err == nil {
-				rss.LastBuildDateParsed = parseDate(rss.LastBuildDate)
+				rss.LastBuildDateParsed = shared.ParseDateTime(rss.LastBuildDate, rp.PreserveTimeZone)
 			}
 		case "generator":
 			rss.Generator, err = shared.ParseText(p)
@@ -204,13 +202,10 @@ func (rp *Parser) parseChannel(p *xpp.Parser) (rss *Feed, err error) {
 	if len(extensions) > 0 {
 		rss.Extensions = extensions
 
-		if itunes, ok := rss.Extensions["itunes"]; ok {
-			rss.ITunesExt = ext.NewITunesFeedExtension(itunes)
-		}
-
-		if dc, ok := rss.Extensions["dc"]; ok {
-			rss.DublinCoreExt = ext.NewDublinCoreExtension(dc)
-		}
+		rss.ITunesExt, _ = ext.Get[*ext.ITunesFeedExtension](extensions)
+		rss.DublinCoreExt, _ = ext.Get[*ext.DublinCoreExtension](extensions)
+		rss.DCTermsExt, _ = ext.Get[*ext.DCTermsExtension](extensions)
+		rss.SyndicationExt, _ = ext.Get[*ext.SyndicationExtension](extensions)
 	}
 
 	return rss, nil
@@ -229,7 +224,7 @@ func (rp *Parser) parseItem(p *xpp.Parser) (item *Item, err error) {
 
 	err = shared.ForEachChild(p, func(name string) error {
 		if shared.IsExtension(p) {
-			extensions, err = shared.ParseExtension(extensions, p)
+			extensions, err = shared.ParseExtension(extensions, p, rp.PreserveExtensionXML)
 			return err
 		}
 		var err error
@@ -254,10 +249,7 @@ func (rp *Parser) parseItem(p *xpp.Parser) (item *Item, err error) {
 			item.Comments, err = shared.ParseTextURL(p)
 		case "pubdate":
 			if item.PubDate, err = shared.ParseText(p); err == nil {
-				if date, derr := shared.ParseDate(item.PubDate); derr == nil {
-					utc := date.UTC()
-					item.PubDateParsed = &utc
-				}
+				item.PubDateParsed = shared.ParseDateTime(item.PubDate, rp.PreserveTimeZone)
 			}
 		case "source":
 			item.Source, err = rp.parseSource(p)
@@ -298,13 +290,9 @@ func (rp *Parser) parseItem(p *xpp.Parser) (item *Item, err error) {
 	if len(extensions) > 0 {
 		item.Extensions = extensions
 
-		if itunes, ok := item.Extensions["itunes"]; ok {
-			item.ITunesExt = ext.NewITunesItemExtension(itunes)
-		}
-
-		if dc, ok := item.Extensions["dc"]; ok {
-			item.DublinCoreExt = ext.NewDublinCoreExtension(dc)
-		}
+		item.ITunesExt, _ = ext.Get[*ext.ITunesItemExtension](extensions)
+		item.DublinCoreExt, _ = ext.Get[*ext.DublinCoreExtension](extensions)
+		item.DCTermsExt, _ = ext.Get[*ext.DCTermsExtension](extensions)
 	}
 
 	if err = p.Expect(xpp.EndTag, "item"); err != nil {
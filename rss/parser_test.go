@@ -8,6 +8,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/mmcdole/gofeed/rss"
 	"github.com/stretchr/testify/assert"
@@ -62,3 +63,22 @@ func TestParser_Parse_UnknownRoot(t *testing.T) {
 	_, err := (&rss.Parser{}).Parse(strings.NewReader(`<foo><channel/></foo>`))
 	assert.Error(t, err)
 }
+
+func TestParser_Parse_PreserveTimeZone(t *testing.T) {
+	feed := `<rss version="2.0"><channel><pubDate>Sat, 05 Oct 2024 14:30:00 +0200</pubDate>` +
+		`<item><pubDate>Sat, 05 Oct 2024 09:00:00 -0500</pubDate></item></channel></rss>`
+
+	actual, err := (&rss.Parser{}).Parse(strings.NewReader(feed))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "2024-10-05T12:30:00Z", actual.PubDateParsed.Format(time.RFC3339))
+	assert.Equal(t, "2024-10-05T14:00:00Z", actual.Items[0].PubDateParsed.Format(time.RFC3339))
+
+	actual, err = (&rss.Parser{PreserveTimeZone: true}).Parse(strings.NewReader(feed))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "2024-10-05T14:30:00+02:00", actual.PubDateParsed.Format(time.RFC3339))
+	assert.Equal(t, "2024-10-05T09:00:00-05:00", actual.Items[0].PubDateParsed.Format(time.RFC3339))
+}
@@ -0,0 +1,296 @@
+package rsscloud
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mmcdole/gofeed"
+	"github.com/mmcdole/gofeed/rss"
+)
+
+// RegistrationLifetime is how long a cloud keeps a registration. Register
+// again within it, daily being the convention, to keep receiving pings.
+const RegistrationLifetime = 25 * time.Hour
+
+// DefaultFetchTimeout bounds the refetch a ping triggers when
+// Subscriber.FetchTimeout is zero.
+const DefaultFetchTimeout = 30 * time.Second
+
+// maxRequestSize bounds the pings and cloud responses read.
+const maxRequestSize = 1 << 20
+
+var (
+	// ErrUnsupportedProtocol is returned by Register for a cloud whose
+	// protocol is neither http-post nor xml-rpc.
+	ErrUnsupportedProtocol = errors.New("rsscloud: unsupported cloud protocol")
+	// ErrNoCloud is returned by Register for a nil cloud.
+	ErrNoCloud = errors.New("rsscloud: feed has no cloud element")
+)
+
+// Subscriber registers with rssCloud servers, the <cloud> element of RSS
+// feeds, and is the http.Handler receiving their pings. Each ping for a
+// registered feed triggers a refetch of the feed through Parser, whose
+// result is handed to OnFeed.
+//
+// The Subscriber must be reachable by the cloud at Domain, Port and Path.
+type Subscriber struct {
+	// Domain is the host the cloud sends pings to. When empty, clouds
+	// using http-post ping the address the registration came from.
+	Domain string
+	// Port and Path locate the Subscriber on the Domain.
+	Port int
+	Path string
+	// NotifyProcedure is the XML-RPC method xml-rpc clouds call to ping.
+	NotifyProcedure string
+	// Client sends registrations. Nil means http.DefaultClient.
+	Client *http.Client
+	// Parser refetches pinged feeds. Nil means gofeed.NewParser().
+	Parser *gofeed.Parser
+	// FetchTimeout bounds each refetch. Zero means DefaultFetchTimeout.
+	FetchTimeout time.Duration
+	// OnFeed is called with the refetched feed after every ping. Refetches
+	// run in their own goroutine, after the ping has been answered.
+	OnFeed func(feedURL string, feed *gofeed.Feed)
+	// OnError is called when a refetch fails.
+	OnError func(feedURL string, err error)
+
+	mu    sync.Mutex
+	feeds map[string]bool
+}
+
+// Register asks cloud to ping the Subscriber when the feed at feedURL
+// changes. Registrations lapse after RegistrationLifetime; call Register
+// again to renew them. Pings are only acted on for feeds registered this
+// way.
+func (s *Subscriber) Register(ctx context.Context, cloud *rss.Cloud, feedURL string) error {
+	if cloud == nil {
+		return ErrNoCloud
+	}
+
+	// Accept pings from the moment the cloud might verify the request.
+	s.mu.Lock()
+	if s.feeds == nil {
+		s.feeds = map[string]bool{}
+	}
+	renewal := s.feeds[feedURL]
+	s.feeds[feedURL] = true
+	s.mu.Unlock()
+
+	endpoint := cloudURL(cloud)
+	var err error
+	switch strings.ToLower(cloud.Protocol) {
+	case "http-post":
+		err = s.registerHTTPPost(ctx, endpoint, feedURL)
+	case "xml-rpc":
+		err = s.registerXMLRPC(ctx, endpoint, cloud.RegisterProcedure, feedURL)
+	default:
+		err = fmt.Errorf("%w: %q", ErrUnsupportedProtocol, cloud.Protocol)
+	}
+	if err != nil && !renewal {
+		s.Forget(feedURL)
+	}
+	return err
+}
+
+// Watching reports whether pings for feedURL are acted on.
+func (s *Subscriber) Watching(feedURL string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.feeds[feedURL]
+}
+
+// Forget stops acting on pings for feedURL.
+func (s *Subscriber) Forget(feedURL string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.feeds, feedURL)
+}
+
+// cloudURL returns the endpoint of cloud; the <cloud> element only names
+// HTTP endpoints.
+func cloudURL(cloud *rss.Cloud) string {
+	host := cloud.Domain
+	if cloud.Port != "" && cloud.Port != "80" {
+		host = net.JoinHostPort(cloud.Domain, cloud.Port)
+	}
+	path := cloud.Path
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	return (&url.URL{Scheme: "http", Host: host, Path: path}).String()
+}
+
+func (s *Subscriber) registerHTTPPost(ctx context.Context, endpoint, feedURL string) error {
+	form := url.Values{
+		"notifyProcedure": {""},
+		"port":            {strconv.Itoa(s.Port)},
+		"path":            {s.Path},
+		"protocol":        {"http-post"},
+		"url1":            {feedURL},
+	}
+	if s.Domain != "" {
+		form.Set("domain", s.Domain)
+	}
+
+	body, err := s.post(ctx, endpoint, "application/x-www-form-urlencoded", []byte(form.Encode()))
+	if err != nil {
+		return err
+	}
+
+	var result struct {
+		Success string `xml:"success,attr"`
+		Msg     string `xml:"msg,attr"`
+	}
+	if err := xml.Unmarshal(body, &result); err != nil {
+		return fmt.Errorf("rsscloud: invalid notifyResult: %w", err)
+	}
+	if !strings.EqualFold(result.Success, "true") {
+		return fmt.Errorf("rsscloud: registration refused: %s", result.Msg)
+	}
+	return nil
+}
+
+func (s *Subscriber) registerXMLRPC(ctx context.Context, endpoint, procedure, feedURL string) error {
+	params := []interface{}{s.NotifyProcedure, s.Port, s.Path, "xml-rpc", []string{feedURL}}
+	if s.Domain != "" {
+		params = append(params, s.Domain)
+	}
+
+	body, err := s.post(ctx, endpoint, "text/xml", encodeCall(procedure, params...))
+	if err != nil {
+		return err
+	}
+
+	value, err := decodeResponse(body)
+	if err != nil {
+		return err
+	}
+	if ok, _ := strconv.ParseBool(value.str()); !ok {
+		return fmt.Errorf("rsscloud: registration refused")
+	}
+	return nil
+}
+
+// post sends a registration and returns the response body.
+func (s *Subscriber) post(ctx context.Context, endpoint, contentType string, body []byte) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", contentType)
+
+	client := s.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, gofeed.HTTPError{StatusCode: resp.StatusCode, Status: resp.Status}
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, maxRequestSize))
+}
+
+// ServeHTTP answers the requests a cloud sends the Subscriber: the
+// http-post challenge (a GET with url and challenge parameters), http-post
+// pings (a form POST with the feed's url) and xml-rpc pings (a call of
+// NotifyProcedure with the feed's URL).
+func (s *Subscriber) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		s.challenge(w, r)
+	case http.MethodPost:
+		if strings.Contains(r.Header.Get("Content-Type"), "xml") {
+			s.pingXMLRPC(w, r)
+		} else {
+			s.pingHTTPPost(w, r)
+		}
+	default:
+		w.Header().Set("Allow", "GET, POST")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *Subscriber) challenge(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	if q.Get("challenge") == "" || !s.Watching(q.Get("url")) {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain")
+	io.WriteString(w, q.Get("challenge"))
+}
+
+func (s *Subscriber) pingHTTPPost(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxRequestSize)
+	feedURL := r.PostFormValue("url")
+	if !s.Watching(feedURL) {
+		http.NotFound(w, r)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	go s.refetch(feedURL)
+}
+
+func (s *Subscriber) pingXMLRPC(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/xml")
+
+	var call xmlrpcCall
+	if err := xml.NewDecoder(io.LimitReader(r.Body, maxRequestSize)).Decode(&call); err != nil {
+		w.Write(encodeFault(1, "invalid method call"))
+		return
+	}
+	if s.NotifyProcedure != "" && call.MethodName != s.NotifyProcedure {
+		w.Write(encodeFault(2, "unknown method "+call.MethodName))
+		return
+	}
+	feedURL := ""
+	if len(call.Params) > 0 {
+		feedURL = call.Params[0].str()
+	}
+	if !s.Watching(feedURL) {
+		w.Write(encodeFault(3, "not registered for "+feedURL))
+		return
+	}
+	w.Write(encodeBoolResponse(true))
+	go s.refetch(feedURL)
+}
+
+func (s *Subscriber) refetch(feedURL string) {
+	timeout := s.FetchTimeout
+	if timeout <= 0 {
+		timeout = DefaultFetchTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	parser := s.Parser
+	if parser == nil {
+		parser = gofeed.NewParser()
+	}
+	feed, err := parser.ParseURLWithContext(feedURL, ctx)
+	if err != nil {
+		if s.OnError != nil {
+			s.OnError(feedURL, err)
+		}
+		return
+	}
+	if s.OnFeed != nil {
+		s.OnFeed(feedURL, feed)
+	}
+}
@@ -0,0 +1,257 @@
+package rsscloud_test
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mmcdole/gofeed"
+	"github.com/mmcdole/gofeed/rss"
+	"github.com/mmcdole/gofeed/rsscloud"
+	"github.com/stretchr/testify/assert"
+)
+
+// registration is what the fake cloud learned from a registration.
+type registration struct {
+	protocol, procedure, port, path, domain, feedURL string
+}
+
+// fakeCloud is an in-process rssCloud server speaking http-post at
+// /http-post and xml-rpc at /RPC2. It challenges http-post registrations
+// that name a domain, as real clouds do.
+type fakeCloud struct {
+	*httptest.Server
+	mu            sync.Mutex
+	registrations []registration
+}
+
+func newFakeCloud(t *testing.T) *fakeCloud {
+	t.Helper()
+	c := &fakeCloud{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/http-post", c.serveHTTPPost)
+	mux.HandleFunc("/RPC2", c.serveXMLRPC)
+	c.Server = httptest.NewServer(mux)
+	t.Cleanup(c.Close)
+	return c
+}
+
+func (c *fakeCloud) serveHTTPPost(w http.ResponseWriter, r *http.Request) {
+	r.ParseForm()
+	reg := registration{
+		protocol: r.PostForm.Get("protocol"),
+		port:     r.PostForm.Get("port"),
+		path:     r.PostForm.Get("path"),
+		domain:   r.PostForm.Get("domain"),
+		feedURL:  r.PostForm.Get("url1"),
+	}
+
+	result := `<notifyResult success="true" msg="Thanks for the registration."/>`
+	challenge := fmt.Sprintf("%s?url=%s&challenge=abc123", reg.endpoint(), url.QueryEscape(reg.feedURL))
+	if resp, err := http.Get(challenge); err != nil {
+		result = `<notifyResult success="false" msg="Could not reach you."/>`
+	} else {
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if string(body) != "abc123" {
+			result = `<notifyResult success="false" msg="The challenge failed."/>`
+		}
+	}
+
+	c.mu.Lock()
+	c.registrations = append(c.registrations, reg)
+	c.mu.Unlock()
+	fmt.Fprint(w, result)
+}
+
+func (c *fakeCloud) serveXMLRPC(w http.ResponseWriter, r *http.Request) {
+	var call struct {
+		MethodName string `xml:"methodName"`
+		Params     []struct {
+			String string   `xml:"string"`
+			Int    string   `xml:"int"`
+			Array  []string `xml:"array>data>value>string"`
+		} `xml:"params>param>value"`
+	}
+	xml.NewDecoder(r.Body).Decode(&call)
+
+	w.Header().Set("Content-Type", "text/xml")
+	if call.MethodName != "rssCloud.pleaseNotify" || len(call.Params) < 6 {
+		fmt.Fprint(w, `<methodResponse><fault><value><struct><member><name>faultString</name>
+			<value><string>bad call</string></value></member></struct></value></fault></methodResponse>`)
+		return
+	}
+	c.mu.Lock()
+	c.registrations = append(c.registrations, registration{
+		protocol:  call.Params[3].String,
+		procedure: call.Params[0].String,
+		port:      call.Params[1].Int,
+		path:      call.Params[2].String,
+		feedURL:   call.Params[4].Array[0],
+		domain:    call.Params[5].String,
+	})
+	c.mu.Unlock()
+	fmt.Fprint(w, `<methodResponse><params><param><value><boolean>1</boolean></value></param></params></methodResponse>`)
+}
+
+func (r registration) endpoint() string {
+	return "http://" + net.JoinHostPort(r.domain, r.port) + r.path
+}
+
+// ping notifies the registered subscriber that its feed changed, returning
+// the response status and body.
+func (r registration) ping(t *testing.T) (int, string) {
+	t.Helper()
+	var resp *http.Response
+	var err error
+	if r.protocol == "xml-rpc" {
+		call := `<?xml version="1.0"?><methodCall><methodName>` + r.procedure + `</methodName>
+			<params><param><value><string>` + r.feedURL + `</string></value></param></params></methodCall>`
+		resp, err = http.Post(r.endpoint(), "text/xml", strings.NewReader(call))
+	} else {
+		resp, err = http.PostForm(r.endpoint(), url.Values{"url": {r.feedURL}})
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	return resp.StatusCode, string(body)
+}
+
+// newSubscriber serves a Subscriber at /rsscloud, sending each feed it
+// refetches to the returned channel.
+func newSubscriber(t *testing.T) (*rsscloud.Subscriber, chan *gofeed.Feed) {
+	t.Helper()
+	feeds := make(chan *gofeed.Feed, 1)
+	s := &rsscloud.Subscriber{
+		Path:            "/rsscloud",
+		NotifyProcedure: "river.feedUpdated",
+		OnFeed: func(feedURL string, feed *gofeed.Feed) {
+			feeds <- feed
+		},
+		OnError: func(feedURL string, err error) {
+			t.Errorf("refetching %s: %v", feedURL, err)
+		},
+	}
+	server := httptest.NewServer(s)
+	t.Cleanup(server.Close)
+
+	u, _ := url.Parse(server.URL)
+	s.Domain = u.Hostname()
+	s.Port, _ = strconv.Atoi(u.Port())
+	return s, feeds
+}
+
+// newFeedServer serves an RSS feed whose cloud element points at cloud.
+func newFeedServer(t *testing.T, cloud *fakeCloud, protocol string) (string, *rss.Cloud) {
+	t.Helper()
+	u, _ := url.Parse(cloud.URL)
+	path, procedure := "/http-post", ""
+	if protocol == "xml-rpc" {
+		path, procedure = "/RPC2", "rssCloud.pleaseNotify"
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `<rss version="2.0"><channel><title>clouded</title>
+			<cloud domain="%s" port="%s" path="%s" registerProcedure="%s" protocol="%s"/>
+			<item><guid>1</guid></item></channel></rss>`, u.Hostname(), u.Port(), path, procedure, protocol)
+	}))
+	t.Cleanup(server.Close)
+
+	feed, err := (&rss.Parser{}).Parse(strings.NewReader(fetch(t, server.URL)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return server.URL, feed.Cloud
+}
+
+func fetch(t *testing.T, u string) string {
+	t.Helper()
+	resp, err := http.Get(u)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	return string(body)
+}
+
+func TestSubscriber_Register(t *testing.T) {
+	for _, protocol := range []string{"http-post", "xml-rpc"} {
+		t.Run(protocol, func(t *testing.T) {
+			cloud := newFakeCloud(t)
+			s, feeds := newSubscriber(t)
+			feedURL, feedCloud := newFeedServer(t, cloud, protocol)
+
+			assert.NoError(t, s.Register(context.Background(), feedCloud, feedURL))
+			assert.True(t, s.Watching(feedURL))
+
+			cloud.mu.Lock()
+			reg := cloud.registrations[0]
+			cloud.mu.Unlock()
+			assert.Equal(t, protocol, reg.protocol)
+			assert.Equal(t, feedURL, reg.feedURL)
+			assert.Equal(t, "/rsscloud", reg.path)
+			assert.Equal(t, strconv.Itoa(s.Port), reg.port)
+
+			// A ping triggers a refetch.
+			status, _ := reg.ping(t)
+			assert.Equal(t, http.StatusOK, status)
+			select {
+			case feed := <-feeds:
+				assert.Equal(t, "clouded", feed.Title)
+			case <-time.After(5 * time.Second):
+				t.Fatal("no refetch after the ping")
+			}
+		})
+	}
+}
+
+func TestSubscriber_IgnoresUnregisteredFeeds(t *testing.T) {
+	s, _ := newSubscriber(t)
+	reg := registration{protocol: "http-post", domain: s.Domain, port: strconv.Itoa(s.Port), path: s.Path,
+		feedURL: "http://example.org/not-registered"}
+	status, _ := reg.ping(t)
+	assert.Equal(t, http.StatusNotFound, status)
+
+	reg.protocol, reg.procedure = "xml-rpc", s.NotifyProcedure
+	_, body := reg.ping(t)
+	assert.Contains(t, body, "<fault>")
+
+	challenge := reg.endpoint() + "?challenge=abc&url=" + url.QueryEscape(reg.feedURL)
+	resp, err := http.Get(challenge)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestSubscriber_RegisterFailures(t *testing.T) {
+	s, _ := newSubscriber(t)
+	ctx := context.Background()
+
+	err := s.Register(ctx, &rss.Cloud{Domain: "example.org", Path: "/soap", Protocol: "soap"}, "http://example.org/feed")
+	assert.ErrorIs(t, err, rsscloud.ErrUnsupportedProtocol)
+	assert.False(t, s.Watching("http://example.org/feed"))
+
+	assert.Equal(t, rsscloud.ErrNoCloud, s.Register(ctx, nil, "http://example.org/feed"))
+
+	// A cloud answering with a fault refuses the registration.
+	cloud := newFakeCloud(t)
+	u, _ := url.Parse(cloud.URL)
+	err = s.Register(ctx, &rss.Cloud{Domain: u.Hostname(), Port: u.Port(), Path: "/RPC2",
+		RegisterProcedure: "wrong.method", Protocol: "xml-rpc"}, "http://example.org/feed")
+	assert.ErrorContains(t, err, "bad call")
+	assert.False(t, s.Watching("http://example.org/feed"))
+}
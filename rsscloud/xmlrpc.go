@@ -0,0 +1,128 @@
+package rsscloud
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// The few XML-RPC shapes rssCloud needs: a call with string, int and
+// string array parameters, and a response holding one value or a fault.
+
+type xmlrpcValue struct {
+	String  *string       `xml:"string"`
+	Int     *string       `xml:"int"`
+	I4      *string       `xml:"i4"`
+	Boolean *string       `xml:"boolean"`
+	Array   []xmlrpcValue `xml:"array>data>value"`
+	Members []struct {
+		Name  string      `xml:"name"`
+		Value xmlrpcValue `xml:"value"`
+	} `xml:"struct>member"`
+	// Text is the content of a value with no type element, a string.
+	Text string `xml:",chardata"`
+}
+
+// str returns the value as a string.
+func (v xmlrpcValue) str() string {
+	for _, s := range []*string{v.String, v.Int, v.I4, v.Boolean} {
+		if s != nil {
+			return strings.TrimSpace(*s)
+		}
+	}
+	return strings.TrimSpace(v.Text)
+}
+
+type xmlrpcCall struct {
+	XMLName    xml.Name      `xml:"methodCall"`
+	MethodName string        `xml:"methodName"`
+	Params     []xmlrpcValue `xml:"params>param>value"`
+}
+
+type xmlrpcResponse struct {
+	XMLName xml.Name      `xml:"methodResponse"`
+	Params  []xmlrpcValue `xml:"params>param>value"`
+	Fault   *xmlrpcValue  `xml:"fault>value"`
+}
+
+// encodeCall encodes a method call whose params are strings, ints and
+// string slices.
+func encodeCall(method string, params ...interface{}) []byte {
+	var b bytes.Buffer
+	b.WriteString(xml.Header)
+	b.WriteString("<methodCall><methodName>")
+	xml.EscapeText(&b, []byte(method))
+	b.WriteString("</methodName><params>")
+	for _, param := range params {
+		b.WriteString("<param><value>")
+		switch v := param.(type) {
+		case string:
+			writeString(&b, v)
+		case int:
+			b.WriteString("<int>" + strconv.Itoa(v) + "</int>")
+		case []string:
+			b.WriteString("<array><data>")
+			for _, s := range v {
+				b.WriteString("<value>")
+				writeString(&b, s)
+				b.WriteString("</value>")
+			}
+			b.WriteString("</data></array>")
+		}
+		b.WriteString("</value></param>")
+	}
+	b.WriteString("</params></methodCall>")
+	return b.Bytes()
+}
+
+func writeString(b *bytes.Buffer, s string) {
+	b.WriteString("<string>")
+	xml.EscapeText(b, []byte(s))
+	b.WriteString("</string>")
+}
+
+// encodeBoolResponse encodes a response returning a boolean.
+func encodeBoolResponse(ok bool) []byte {
+	value := "0"
+	if ok {
+		value = "1"
+	}
+	return []byte(xml.Header + "<methodResponse><params><param><value><boolean>" + value +
+		"</boolean></value></param></params></methodResponse>")
+}
+
+// encodeFault encodes a fault response.
+func encodeFault(code int, message string) []byte {
+	var b bytes.Buffer
+	b.WriteString(xml.Header)
+	b.WriteString("<methodResponse><fault><value><struct>")
+	b.WriteString("<member><name>faultCode</name><value><int>" + strconv.Itoa(code) + "</int></value></member>")
+	b.WriteString("<member><name>faultString</name><value>")
+	writeString(&b, message)
+	b.WriteString("</value></member></struct></value></fault></methodResponse>")
+	return b.Bytes()
+}
+
+// decodeResponse returns the value a response holds, or its fault as an
+// error.
+func decodeResponse(data []byte) (xmlrpcValue, error) {
+	var resp xmlrpcResponse
+	if err := xml.Unmarshal(data, &resp); err != nil {
+		return xmlrpcValue{}, fmt.Errorf("rsscloud: invalid XML-RPC response: %w", err)
+	}
+	if resp.Fault != nil {
+		message := "unknown fault"
+		for _, m := range resp.Fault.Members {
+			if m.Name == "faultString" {
+				message = m.Value.str()
+			}
+		}
+		return xmlrpcValue{}, fmt.Errorf("rsscloud: XML-RPC fault: %s", message)
+	}
+	if len(resp.Params) == 0 {
+		return xmlrpcValue{}, fmt.Errorf("rsscloud: XML-RPC response has no value")
+	}
+	return resp.Params[0], nil
+}
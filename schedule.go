@@ -0,0 +1,185 @@
+package gofeed
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	ext "github.com/mmcdole/gofeed/extensions"
+	"github.com/mmcdole/gofeed/internal/shared"
+)
+
+// UpdateSchedule collects the publisher's hints about how often a feed
+// should be polled: the RSS ttl, skipHours and skipDays elements and the
+// RSS 1.0 Syndication module (sy:updatePeriod, sy:updateFrequency and
+// sy:updateBase), normalized into one format-independent form.
+type UpdateSchedule struct {
+	// TTL is how long the feed may be cached before refreshing (RSS ttl).
+	TTL time.Duration `json:"ttl,omitempty"`
+	// Period and Frequency describe the publishing schedule: the feed is
+	// updated Frequency times per Period (hourly, daily, weekly, monthly or
+	// yearly).
+	Period    string `json:"period,omitempty"`
+	Frequency int    `json:"frequency,omitempty"`
+	// Base anchors the Period/Frequency schedule; updates fall on Base plus
+	// whole multiples of the update interval.
+	Base *time.Time `json:"base,omitempty"`
+	// SkipHours are the hours of the day (0-23, GMT) in which the feed
+	// should not be polled.
+	SkipHours []int `json:"skipHours,omitempty"`
+	// SkipDays are the days of the week (in GMT) on which the feed should
+	// not be polled.
+	SkipDays []time.Weekday `json:"skipDays,omitempty"`
+}
+
+// syndicationPeriods maps sy:updatePeriod values to their length. Months
+// and years are approximated; the schedule is a polling hint, not a
+// calendar.
+var syndicationPeriods = map[string]time.Duration{
+	"hourly":  time.Hour,
+	"daily":   24 * time.Hour,
+	"weekly":  7 * 24 * time.Hour,
+	"monthly": 30 * 24 * time.Hour,
+	"yearly":  365 * 24 * time.Hour,
+}
+
+// UpdateInterval returns the time between updates implied by Period and
+// Frequency, or zero when the schedule carries no syndication hints.
+func (s *UpdateSchedule) UpdateInterval() time.Duration {
+	period, ok := syndicationPeriods[s.Period]
+	if !ok {
+		return 0
+	}
+	frequency := s.Frequency
+	if frequency < 1 {
+		frequency = 1
+	}
+	return period / time.Duration(frequency)
+}
+
+// NextUpdate returns the earliest time after a fetch at last at which the
+// feed should be polled again. It waits at least TTL and until the next
+// update slot: one interval after last, or with Base set the first slot
+// after last aligned to Base, then moves forward past any skipped
+// hours and days. A schedule that skips every hour of the week is ignored
+// rather than never polling again.
+func (s *UpdateSchedule) NextUpdate(last time.Time) time.Time {
+	next := last
+	if s.TTL > 0 {
+		next = last.Add(s.TTL)
+	}
+
+	if interval := s.UpdateInterval(); interval > 0 {
+		scheduled := last.Add(interval)
+		if s.Base != nil {
+			// The first slot Base plus or minus a whole number of intervals
+			// after last, found from the remainder rather than by
+			// multiplying the step count back, which overflows for a Base
+			// centuries away. Sub saturates there, which only loses the
+			// alignment. A Base equal to last moves on a full interval.
+			if !last.Before(*s.Base) {
+				scheduled = last.Add(interval - last.Sub(*s.Base)%interval)
+			} else {
+				scheduled = last.Add((s.Base.Sub(last)-1)%interval + 1)
+			}
+		}
+		if scheduled.After(next) {
+			next = scheduled
+		}
+	}
+
+	// Walk forward an hour at a time, at most one week, until the hour and
+	// day are both allowed. The first step lands on the top of the hour.
+	// skipHours and skipDays are in GMT, but the result is given in the
+	// location of last.
+	candidate := next
+	for i := 0; i < 7*24; i++ {
+		if !s.skipped(candidate) {
+			return candidate.In(last.Location())
+		}
+		candidate = candidate.UTC().Truncate(time.Hour).Add(time.Hour)
+	}
+	return next.In(last.Location())
+}
+
+func (s *UpdateSchedule) skipped(t time.Time) bool {
+	t = t.UTC()
+	for _, h := range s.SkipHours {
+		if t.Hour() == h {
+			return true
+		}
+	}
+	for _, d := range s.SkipDays {
+		if t.Weekday() == d {
+			return true
+		}
+	}
+	return false
+}
+
+// newUpdateSchedule builds an UpdateSchedule from the raw RSS ttl,
+// skipHours and skipDays values and the syndication module. Values that do
+// not parse are dropped. It returns nil when no hint is present.
+func newUpdateSchedule(ttl string, skipHours, skipDays []string, sy *ext.SyndicationExtension) *UpdateSchedule {
+	s := &UpdateSchedule{}
+
+	if minutes, err := strconv.Atoi(strings.TrimSpace(ttl)); err == nil && minutes > 0 {
+		s.TTL = time.Duration(minutes) * time.Minute
+	}
+
+	for _, h := range skipHours {
+		// RSS 2.0 numbers hours 0-23, but some publishers use 24 for midnight.
+		hour, err := strconv.Atoi(strings.TrimSpace(h))
+		if err != nil || hour < 0 || hour > 24 {
+			continue
+		}
+		if hour %= 24; !slices.Contains(s.SkipHours, hour) {
+			s.SkipHours = append(s.SkipHours, hour)
+		}
+	}
+
+	for _, d := range skipDays {
+		if day, ok := parseWeekday(d); ok && !slices.Contains(s.SkipDays, day) {
+			s.SkipDays = append(s.SkipDays, day)
+		}
+	}
+
+	if sy != nil {
+		period := strings.ToLower(strings.TrimSpace(sy.UpdatePeriod))
+		frequency := strings.TrimSpace(sy.UpdateFrequency)
+		if _, ok := syndicationPeriods[period]; ok || frequency != "" {
+			// The module defaults to once daily when either value is missing.
+			if !ok {
+				period = "daily"
+			}
+			s.Period = period
+			s.Frequency = 1
+			if n, err := strconv.Atoi(frequency); err == nil && n > 0 {
+				s.Frequency = n
+			}
+		}
+		if sy.UpdateBase != "" {
+			if base, err := shared.ParseDate(sy.UpdateBase); err == nil {
+				s.Base = &base
+			}
+		}
+	}
+
+	if s.TTL == 0 && s.Period == "" && s.Base == nil && s.SkipHours == nil && s.SkipDays == nil {
+		return nil
+	}
+	return s
+}
+
+// parseWeekday parses an RSS skipDays day name ("Monday" ... "Sunday"),
+// case-insensitively.
+func parseWeekday(name string) (time.Weekday, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		if strings.ToLower(d.String()) == name {
+			return d, true
+		}
+	}
+	return 0, false
+}
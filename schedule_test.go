@@ -0,0 +1,155 @@
+package gofeed_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mmcdole/gofeed"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdateSchedule_NextUpdate(t *testing.T) {
+	// Wednesday.
+	last := time.Date(2026, 3, 4, 10, 30, 0, 0, time.UTC)
+	base := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		schedule gofeed.UpdateSchedule
+		want     time.Time
+	}{
+		{
+			name:     "no hints",
+			schedule: gofeed.UpdateSchedule{},
+			want:     last,
+		},
+		{
+			name:     "ttl",
+			schedule: gofeed.UpdateSchedule{TTL: 90 * time.Minute},
+			want:     last.Add(90 * time.Minute),
+		},
+		{
+			name:     "period and frequency",
+			schedule: gofeed.UpdateSchedule{Period: "daily", Frequency: 4},
+			want:     last.Add(6 * time.Hour),
+		},
+		{
+			name:     "aligned to base",
+			schedule: gofeed.UpdateSchedule{Period: "daily", Frequency: 4, Base: &base},
+			want:     time.Date(2026, 3, 4, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "ttl longer than interval wins",
+			schedule: gofeed.UpdateSchedule{TTL: 3 * time.Hour, Period: "hourly", Frequency: 1},
+			want:     last.Add(3 * time.Hour),
+		},
+		{
+			name:     "skipped hours",
+			schedule: gofeed.UpdateSchedule{TTL: time.Hour, SkipHours: []int{11, 12}},
+			want:     time.Date(2026, 3, 4, 13, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "skipped days",
+			schedule: gofeed.UpdateSchedule{SkipDays: []time.Weekday{time.Wednesday, time.Thursday}},
+			want:     time.Date(2026, 3, 6, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "everything skipped",
+			schedule: gofeed.UpdateSchedule{
+				TTL:      time.Hour,
+				SkipDays: []time.Weekday{0, 1, 2, 3, 4, 5, 6},
+			},
+			want: last.Add(time.Hour),
+		},
+	}
+
+	for _, test := range tests {
+		got := test.schedule.NextUpdate(last)
+		assert.True(t, test.want.Equal(got), "%s: NextUpdate = %v, want %v", test.name, got, test.want)
+	}
+}
+
+// A Base equal to last waits a full interval rather than polling again at
+// once, and a Base far ahead of last is brought back to the first aligned
+// slot after it rather than putting polling off until then.
+func TestUpdateSchedule_NextUpdate_BaseEdges(t *testing.T) {
+	last := time.Date(2026, 3, 4, 10, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		base time.Time
+		want time.Time
+	}{
+		{
+			name: "base equals last",
+			base: last,
+			want: last.Add(6 * time.Hour),
+		},
+		{
+			name: "base far in the future",
+			base: last.Add(5*24*time.Hour + 90*time.Minute),
+			want: last.Add(90 * time.Minute),
+		},
+		{
+			name: "base one interval ahead",
+			base: last.Add(6 * time.Hour),
+			want: last.Add(6 * time.Hour),
+		},
+	}
+
+	for _, test := range tests {
+		base := test.base
+		s := gofeed.UpdateSchedule{Period: "daily", Frequency: 4, Base: &base}
+		got := s.NextUpdate(last)
+		assert.True(t, test.want.Equal(got), "%s: NextUpdate = %v, want %v", test.name, got, test.want)
+	}
+}
+
+// The result is in the location of last, even after skipping hours, which
+// are counted in GMT.
+func TestUpdateSchedule_NextUpdate_Location(t *testing.T) {
+	zone := time.FixedZone("EST", -5*60*60)
+	last := time.Date(2026, 3, 4, 5, 30, 0, 0, zone) // 10:30 GMT
+
+	s := gofeed.UpdateSchedule{TTL: time.Hour, SkipHours: []int{11, 12}}
+	got := s.NextUpdate(last)
+	assert.Equal(t, time.Date(2026, 3, 4, 8, 0, 0, 0, zone), got)
+	assert.Equal(t, zone, got.Location())
+}
+
+// A Base too old for the time since it to fit in a time.Duration must not
+// overflow into a time before last.
+func TestUpdateSchedule_NextUpdate_AncientBase(t *testing.T) {
+	last := time.Date(2026, 3, 4, 10, 30, 0, 0, time.UTC)
+	base := time.Date(1, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	s := gofeed.UpdateSchedule{Period: "hourly", Frequency: 1, Base: &base}
+	got := s.NextUpdate(last)
+	assert.True(t, got.After(last), "NextUpdate = %v", got)
+	assert.False(t, got.After(last.Add(time.Hour)), "NextUpdate = %v", got)
+}
+
+func TestUpdateSchedule_Translated(t *testing.T) {
+	feed := `<rss version="2.0" xmlns:sy="http://purl.org/rss/1.0/modules/syndication/"><channel>
+		<ttl>30</ttl>
+		<sy:updatePeriod>hourly</sy:updatePeriod>
+		<sy:updateBase>2000-01-01T12:00+00:00</sy:updateBase>
+	</channel></rss>`
+
+	f, err := gofeed.NewParser().ParseString(feed)
+	assert.NoError(t, err)
+	if assert.NotNil(t, f.UpdateSchedule) {
+		assert.Equal(t, 30*time.Minute, f.UpdateSchedule.TTL)
+		assert.Equal(t, "hourly", f.UpdateSchedule.Period)
+		assert.Equal(t, 1, f.UpdateSchedule.Frequency)
+		assert.Equal(t, time.Hour, f.UpdateSchedule.UpdateInterval())
+		if assert.NotNil(t, f.UpdateSchedule.Base) {
+			assert.True(t, f.UpdateSchedule.Base.Equal(time.Date(2000, 1, 1, 12, 0, 0, 0, time.UTC)))
+		}
+	}
+
+	// A feed without hints has no schedule.
+	f, err = gofeed.NewParser().ParseString(`<rss version="2.0"><channel><title>t</title></channel></rss>`)
+	assert.NoError(t, err)
+	assert.Nil(t, f.UpdateSchedule)
+}
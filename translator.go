@@ -3,11 +3,14 @@ package gofeed
 import (
 	"bytes"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/mmcdole/gofeed/activitystreams"
 	"github.com/mmcdole/gofeed/atom"
 	ext "github.com/mmcdole/gofeed/extensions"
+	"github.com/mmcdole/gofeed/hfeed"
 	"github.com/mmcdole/gofeed/internal/shared"
 	"github.com/mmcdole/gofeed/json"
 	"github.com/mmcdole/gofeed/rss"
@@ -49,6 +52,8 @@ func (t *DefaultRSSTranslator) Translate(feed interface{}) (*Feed, error) {
 		Generator:       rss.Generator,
 		ITunesExt:       rss.ITunesExt,
 		DublinCoreExt:   rss.DublinCoreExt,
+		DCTermsExt:      rss.DCTermsExt,
+		SyndicationExt:  rss.SyndicationExt,
 		Extensions:      rss.Extensions,
 		FeedVersion:     rss.Version,
 		FeedType:        "rss",
@@ -80,15 +85,25 @@ func (t *DefaultRSSTranslator) Translate(feed interface{}) (*Feed, error) {
 	if result.Updated == "" && dc != nil {
 		result.Updated = firstString(dc.Date)
 	}
+	if result.Updated == "" && rss.DCTermsExt != nil {
+		result.Updated = firstString(rss.DCTermsExt.Modified)
+	}
 	result.UpdatedParsed = rss.LastBuildDateParsed
 	if result.UpdatedParsed == nil && dc != nil && dc.Date != nil {
 		if date, err := shared.ParseDate(firstString(dc.Date)); err == nil {
 			result.UpdatedParsed = &date
 		}
 	}
+	if result.UpdatedParsed == nil && rss.DCTermsExt != nil && rss.DCTermsExt.Modified != nil {
+		if date, err := shared.ParseDate(firstString(rss.DCTermsExt.Modified)); err == nil {
+			result.UpdatedParsed = &date
+		}
+	}
 
 	result.Link = t.translateFeedLink(rss)
 	result.FeedLink = t.translateFeedFeedLink(rss)
+	result.NextURL = firstString(t.atomExtLinkHrefs(rss.Extensions, "next"))
+	result.Hubs = t.atomExtLinkHrefs(rss.Extensions, "hub")
 	result.Links = t.translateFeedLinks(rss)
 
 	if author := t.translateFeedAuthor(rss); author != nil {
@@ -98,6 +113,12 @@ func (t *DefaultRSSTranslator) Translate(feed interface{}) (*Feed, error) {
 
 	result.Image = t.translateFeedImage(rss)
 	result.Categories = t.translateFeedCategories(rss)
+	result.UpdateSchedule = newUpdateSchedule(rss.TTL, rss.SkipHours, rss.SkipDays, rss.SyndicationExt)
+	result.License = translateLicense(t.atomExtLinkHrefs(rss.Extensions, "license"), rss.Extensions)
+	result.History = newFeedHistory(rss.Extensions, func(rel string) string {
+		return firstString(t.atomExtLinkHrefs(rss.Extensions, rel))
+	})
+	result.DeletedItemIDs = t.translateFeedDeletedItemIDs(rss)
 
 	result.Items = make([]*Item, 0, len(rss.Items))
 	for _, i := range rss.Items {
@@ -111,6 +132,7 @@ func (t *DefaultRSSTranslator) translateFeedItem(rssItem *rss.Item) *Item {
 	item := &Item{
 		Link:          rssItem.Link,
 		DublinCoreExt: rssItem.DublinCoreExt,
+		DCTermsExt:    rssItem.DCTermsExt,
 		ITunesExt:     rssItem.ITunesExt,
 		Extensions:    rssItem.Extensions,
 		Custom:        rssItem.Custom,
@@ -151,6 +173,9 @@ func (t *DefaultRSSTranslator) translateFeedItem(rssItem *rss.Item) *Item {
 	item.Image = t.translateItemImage(rssItem)
 	item.Categories = t.translateItemCategories(rssItem)
 	item.Enclosures = t.translateItemEnclosures(rssItem)
+	item.License = translateLicense(t.atomExtLinkHrefs(rssItem.Extensions, "license"), rssItem.Extensions)
+	item.Comments = translateComments(rssItem.Comments, t.translateItemReplyLinks(rssItem), rssItem.Extensions)
+	item.InReplyTo = translateInReplyTo(rssItem.Extensions)
 	return item
 }
 
@@ -309,6 +334,9 @@ func (t *DefaultRSSTranslator) translateItemUpdated(rssItem *rss.Item) (updated
 	if updated == "" {
 		updated = t.atomExtValue(rssItem.Extensions, "updated")
 	}
+	if updated == "" && rssItem.DCTermsExt != nil {
+		updated = firstString(rssItem.DCTermsExt.Modified)
+	}
 	return updated
 }
 
@@ -327,7 +355,10 @@ func (t *DefaultRSSTranslator) translateItemPublished(rssItem *rss.Item) (pubDat
 	} else if rssItem.DublinCoreExt != nil && rssItem.DublinCoreExt.Date != nil {
 		return firstString(rssItem.DublinCoreExt.Date)
 	}
-	return t.atomExtValue(rssItem.Extensions, "published")
+	if published := t.atomExtValue(rssItem.Extensions, "published"); published != "" {
+		return published
+	}
+	return dctermsPublished(rssItem.DCTermsExt)
 }
 
 func (t *DefaultRSSTranslator) translateItemPublishedParsed(rssItem *rss.Item) (pubDate *time.Time) {
@@ -341,6 +372,9 @@ func (t *DefaultRSSTranslator) translateItemPublishedParsed(rssItem *rss.Item) (
 	if pubDateText == "" {
 		pubDateText = t.atomExtValue(rssItem.Extensions, "published")
 	}
+	if pubDateText == "" {
+		pubDateText = dctermsPublished(rssItem.DCTermsExt)
+	}
 	if pubDateText != "" {
 		if pubDateParsed, err := shared.ParseDate(pubDateText); err == nil {
 			pubDate = &pubDateParsed
@@ -437,6 +471,8 @@ func (t *DefaultRSSTranslator) translateItemCategories(rssItem *rss.Item) (categ
 	return
 }
 
+// translateItemEnclosures converts the item's enclosures. An itunes:duration
+// describes the episode, so it only gives the duration of a lone enclosure.
 func (t *DefaultRSSTranslator) translateItemEnclosures(rssItem *rss.Item) (enclosures []*Enclosure) {
 	for _, enc := range rssItem.Enclosures {
 		enclosures = append(enclosures, &Enclosure{
@@ -445,6 +481,26 @@ func (t *DefaultRSSTranslator) translateItemEnclosures(rssItem *rss.Item) (enclo
 			Length: enc.Length,
 		})
 	}
+	if len(enclosures) == 1 && rssItem.ITunesExt != nil {
+		enclosures[0].Duration = parseITunesDuration(rssItem.ITunesExt.Duration)
+	}
+	return
+}
+
+// translateItemReplyLinks collects embedded atom:link elements with
+// rel="replies".
+func (t *DefaultRSSTranslator) translateItemReplyLinks(rssItem *rss.Item) (replies []replyLink) {
+	for _, m := range t.extensionsForKeys([]string{"atom", "atom10", "atom03"}, rssItem.Extensions) {
+		for _, l := range m["link"] {
+			if l.Attrs["rel"] == "replies" {
+				replies = append(replies, replyLink{
+					href:      l.Attrs["href"],
+					mediaType: l.Attrs["type"],
+					count:     l.Attrs["count"],
+				})
+			}
+		}
+	}
 	return
 }
 
@@ -463,6 +519,39 @@ func (t *DefaultRSSTranslator) extensionsForKeys(keys []string, extensions ext.E
 	return
 }
 
+// translateFeedDeletedItemIDs returns the refs of RFC 6721 tombstones
+// (at:deleted-entry) embedded in an RSS channel. The ref is read from the
+// attribute RFC 6721 defines or, failing that, from a ref child element.
+func (t *DefaultRSSTranslator) translateFeedDeletedItemIDs(rss *rss.Feed) (ids []string) {
+	prefix, ok := ext.RegisteredPrefix("http://purl.org/atompub/tombstones/1.0")
+	if !ok {
+		prefix = "at"
+	}
+	for _, deleted := range rss.Extensions[prefix]["deleted-entry"] {
+		ref := strings.TrimSpace(deleted.Attrs["ref"])
+		if ref == "" && len(deleted.Children["ref"]) > 0 {
+			ref = strings.TrimSpace(deleted.Children["ref"][0].Value)
+		}
+		if ref != "" {
+			ids = append(ids, ref)
+		}
+	}
+	return
+}
+
+// atomExtLinkHrefs returns the hrefs of embedded atom:link elements with the
+// given rel.
+func (t *DefaultRSSTranslator) atomExtLinkHrefs(exts ext.Extensions, rel string) (hrefs []string) {
+	for _, m := range t.extensionsForKeys([]string{"atom", "atom10", "atom03"}, exts) {
+		for _, l := range m["link"] {
+			if l.Attrs["rel"] == rel && l.Attrs["href"] != "" {
+				hrefs = append(hrefs, l.Attrs["href"])
+			}
+		}
+	}
+	return
+}
+
 // atomExtValue returns the text of the first matching Atom element embedded in
 // an RSS feed (across the atom/atom10/atom03 namespaces), or "" if absent. This
 // promotes Atom tags in RSS to the universal fields, the same way dc:/itunes:
@@ -489,6 +578,21 @@ func (t *DefaultRSSTranslator) atomExtChild(exts ext.Extensions, parent, child s
 	return ""
 }
 
+// dctermsPublished returns the first of dcterms:issued, dcterms:created and
+// dcterms:date, the terms feeds that date items only with DCMI terms use for
+// publication, or "" when none is present.
+func dctermsPublished(dcterms *ext.DCTermsExtension) string {
+	if dcterms == nil {
+		return ""
+	}
+	for _, dates := range [][]string{dcterms.Issued, dcterms.Created, dcterms.Date} {
+		if date := firstString(dates); date != "" {
+			return date
+		}
+	}
+	return ""
+}
+
 // firstString returns the first entry of a string slice, or "" when empty.
 func firstString(entries []string) string {
 	if len(entries) == 0 {
@@ -504,6 +608,100 @@ func personFromText(text string) *Person {
 	return &Person{Name: name, Email: address}
 }
 
+// replyLink is a rel="replies" link (RFC 4685), whether from an Atom entry
+// or an atom:link embedded in an RSS item.
+type replyLink struct {
+	href      string
+	mediaType string
+	count     string
+}
+
+// translateComments builds the universal Comments from the item's comments
+// page URL, its rel="replies" links and the wfw, slash and thr extensions.
+// An HTML replies link is a comments page; any other is a comments feed. The
+// count prefers thr:total, then slash:comments, then a link's thr:count. It
+// returns nil when the item carries no comment information.
+func translateComments(pageURL string, replies []replyLink, exts ext.Extensions) *Comments {
+	c := &Comments{URL: pageURL}
+	var linkCount *int
+	for _, r := range replies {
+		if strings.Contains(r.mediaType, "html") {
+			if c.URL == "" {
+				c.URL = r.href
+			}
+		} else if c.FeedURL == "" {
+			c.FeedURL = r.href
+		}
+		if linkCount == nil {
+			linkCount = parseCount(r.count)
+		}
+	}
+
+	if wfw, ok := ext.Get[*ext.WellFormedWebExtension](exts); ok && c.FeedURL == "" {
+		c.FeedURL = wfw.CommentRSS
+	}
+	if thr, ok := ext.Get[*ext.ThreadingExtension](exts); ok {
+		c.Count = parseCount(thr.Total)
+	}
+	if slash, ok := ext.Get[*ext.SlashExtension](exts); ok && c.Count == nil {
+		c.Count = parseCount(slash.Comments)
+	}
+	if c.Count == nil {
+		c.Count = linkCount
+	}
+
+	if c.URL == "" && c.FeedURL == "" && c.Count == nil {
+		return nil
+	}
+	return c
+}
+
+// translateInReplyTo converts thr:in-reply-to extensions to universal
+// InReplyTo references.
+func translateInReplyTo(exts ext.Extensions) (replies []*InReplyTo) {
+	thr, ok := ext.Get[*ext.ThreadingExtension](exts)
+	if !ok {
+		return nil
+	}
+	for _, r := range thr.InReplyTo {
+		replies = append(replies, &InReplyTo{
+			Ref:    r.Ref,
+			Href:   r.Href,
+			Type:   r.Type,
+			Source: r.Source,
+		})
+	}
+	return
+}
+
+// parseITunesDuration parses an itunes:duration given as seconds, MM:SS or
+// HH:MM:SS, returning zero when it does not parse.
+func parseITunesDuration(text string) time.Duration {
+	parts := strings.Split(strings.TrimSpace(text), ":")
+	if len(parts) > 3 {
+		return 0
+	}
+	var seconds float64
+	for _, part := range parts {
+		n, err := strconv.ParseFloat(part, 64)
+		if err != nil || n < 0 {
+			return 0
+		}
+		seconds = seconds*60 + n
+	}
+	return time.Duration(seconds * float64(time.Second))
+}
+
+// parseCount parses a non-negative comment count, returning nil when the
+// text is empty or not a number.
+func parseCount(text string) *int {
+	n, err := strconv.Atoi(strings.TrimSpace(text))
+	if err != nil || n < 0 {
+		return nil
+	}
+	return &n
+}
+
 func firstImageFromHtmlDocument(document string) *Image {
 	doc, err := html.Parse(bytes.NewBufferString(document))
 	if err != nil {
@@ -565,12 +763,18 @@ func (t *DefaultAtomTranslator) Translate(feed interface{}) (*Feed, error) {
 		FeedType:      "atom",
 	}
 
+	result.DCTermsExt, _ = ext.Get[*ext.DCTermsExtension](atomFeed.Extensions)
+
 	if l := firstLinkWithRel("alternate", atomFeed.Links); l != nil {
 		result.Link = l.Href
 	}
 	if l := firstLinkWithRel("self", atomFeed.Links); l != nil {
 		result.FeedLink = l.Href
 	}
+	if l := firstLinkWithRel("next", atomFeed.Links); l != nil {
+		result.NextURL = l.Href
+	}
+	result.Hubs = linkHrefsWithRel("hub", atomFeed.Links)
 	for _, l := range atomFeed.Links {
 		if l.Rel == "" || l.Rel == "alternate" || l.Rel == "self" {
 			result.Links = append(result.Links, l.Href)
@@ -587,6 +791,10 @@ func (t *DefaultAtomTranslator) Translate(feed interface{}) (*Feed, error) {
 	} else if atomFeed.Icon != "" {
 		result.Image = &Image{URL: atomFeed.Icon}
 	}
+	// The Atom icon is the small, square image a favicon is.
+	if atomFeed.Icon != "" {
+		result.Favicon = &Image{URL: atomFeed.Icon}
+	}
 
 	if atomFeed.Generator != nil {
 		generator := atomFeed.Generator.Value
@@ -600,6 +808,22 @@ func (t *DefaultAtomTranslator) Translate(feed interface{}) (*Feed, error) {
 	}
 
 	result.Categories = atomCategories(atomFeed.Categories)
+	result.License = translateLicense(linkHrefsWithRel("license", atomFeed.Links), atomFeed.Extensions)
+	result.History = newFeedHistory(atomFeed.Extensions, func(rel string) string {
+		return firstString(linkHrefsWithRel(rel, atomFeed.Links))
+	})
+	for _, deleted := range atomFeed.DeletedEntries {
+		if deleted.Ref != "" {
+			result.DeletedItemIDs = append(result.DeletedItemIDs, deleted.Ref)
+		}
+	}
+
+	// Atom has no ttl or skip rules of its own, but the syndication module
+	// is commonly embedded.
+	if sy, ok := ext.Get[*ext.SyndicationExtension](atomFeed.Extensions); ok {
+		result.SyndicationExt = sy
+		result.UpdateSchedule = newUpdateSchedule("", nil, nil, result.SyndicationExt)
+	}
 
 	result.Items = make([]*Item, 0, len(atomFeed.Entries))
 	for _, entry := range atomFeed.Entries {
@@ -623,21 +847,45 @@ func (t *DefaultAtomTranslator) translateFeedItem(entry *atom.Entry) *Item {
 		item.Content = entry.Content.Value
 	}
 
+	item.DCTermsExt, _ = ext.Get[*ext.DCTermsExtension](entry.Extensions)
+
 	if l := firstLinkWithRel("alternate", entry.Links); l != nil {
 		item.Link = l.Href
 	}
+	// A rel="related" link is the page a linkblog entry points to.
+	if l := firstLinkWithRel("related", entry.Links); l != nil {
+		item.ExternalURL = l.Href
+	}
 	for _, l := range entry.Links {
 		if l.Rel == "" || l.Rel == "alternate" || l.Rel == "self" {
 			item.Links = append(item.Links, l.Href)
 		}
 	}
 
-	// Published falls back to the update time when absent.
+	// Updated falls back to dcterms:modified, and Published to the dcterms
+	// publication dates and then the update time.
+	if item.Updated == "" && item.DCTermsExt != nil {
+		item.Updated = firstString(item.DCTermsExt.Modified)
+	}
+	if item.UpdatedParsed == nil && item.Updated != "" {
+		if date, err := shared.ParseDate(item.Updated); err == nil {
+			item.UpdatedParsed = &date
+		}
+	}
+
 	item.Published = entry.Published
+	if item.Published == "" {
+		item.Published = dctermsPublished(item.DCTermsExt)
+	}
 	if item.Published == "" {
 		item.Published = entry.Updated
 	}
 	item.PublishedParsed = entry.PublishedParsed
+	if item.PublishedParsed == nil {
+		if date, err := shared.ParseDate(dctermsPublished(item.DCTermsExt)); err == nil {
+			item.PublishedParsed = &date
+		}
+	}
 	if item.PublishedParsed == nil {
 		item.PublishedParsed = entry.UpdatedParsed
 	}
@@ -649,16 +897,25 @@ func (t *DefaultAtomTranslator) translateFeedItem(entry *atom.Entry) *Item {
 
 	item.Categories = atomCategories(entry.Categories)
 
+	var replies []replyLink
 	for _, l := range entry.Links {
-		if l.Rel == "enclosure" {
+		switch l.Rel {
+		case "enclosure":
 			item.Enclosures = append(item.Enclosures, &Enclosure{
 				URL:    l.Href,
 				Length: l.Length,
 				Type:   l.Type,
+				Title:  l.Title,
 			})
+		case "replies":
+			replies = append(replies, replyLink{href: l.Href, mediaType: l.Type, count: l.ThreadCount})
 		}
 	}
 
+	item.License = translateLicense(linkHrefsWithRel("license", entry.Links), entry.Extensions)
+	item.Comments = translateComments("", replies, entry.Extensions)
+	item.InReplyTo = translateInReplyTo(entry.Extensions)
+
 	return item
 }
 
@@ -672,6 +929,16 @@ func firstLinkWithRel(rel string, links []*atom.Link) *atom.Link {
 	return nil
 }
 
+// linkHrefsWithRel returns the hrefs of all links carrying the given rel.
+func linkHrefsWithRel(rel string, links []*atom.Link) (hrefs []string) {
+	for _, link := range links {
+		if link.Rel == rel && link.Href != "" {
+			hrefs = append(hrefs, link.Href)
+		}
+	}
+	return
+}
+
 // atomPersons converts atom persons to universal Persons.
 func atomPersons(persons []*atom.Person) []*Person {
 	if persons == nil {
@@ -679,7 +946,7 @@ func atomPersons(persons []*atom.Person) []*Person {
 	}
 	out := make([]*Person, 0, len(persons))
 	for _, p := range persons {
-		out = append(out, &Person{Name: p.Name, Email: p.Email})
+		out = append(out, &Person{Name: p.Name, Email: p.Email, URL: p.URI})
 	}
 	return out
 }
@@ -723,10 +990,22 @@ func (t *DefaultJSONTranslator) Translate(feed interface{}) (*Feed, error) {
 		Link:        jsonFeed.HomePageURL,
 		FeedLink:    jsonFeed.FeedURL,
 		Description: jsonFeed.Description,
+		UserComment: jsonFeed.UserComment,
+		NextURL:     jsonFeed.NextURL,
+		Expired:     jsonFeed.Expired,
 		Language:    jsonFeed.Language,
+		Extensions:  jsonFeed.Extensions,
 		FeedType:    "json",
 	}
 
+	// Only WebSub hubs are kept; rssCloud endpoints are not hubs in the
+	// sense Feed.Hubs uses.
+	for _, hub := range jsonFeed.Hubs {
+		if hub.URL != "" && (strings.EqualFold(hub.Type, "WebSub") || strings.EqualFold(hub.Type, "PubSubHubbub")) {
+			result.Hubs = append(result.Hubs, hub.URL)
+		}
+	}
+
 	if jsonFeed.HomePageURL != "" {
 		result.Links = append(result.Links, jsonFeed.HomePageURL)
 	}
@@ -739,9 +1018,12 @@ func (t *DefaultJSONTranslator) Translate(feed interface{}) (*Feed, error) {
 	if jsonFeed.Icon != "" {
 		result.Image = &Image{URL: jsonFeed.Icon}
 	}
+	if jsonFeed.Favicon != "" {
+		result.Favicon = &Image{URL: jsonFeed.Favicon}
+	}
 
 	if jsonFeed.Author != nil {
-		result.Author = personFromText(jsonFeed.Author.Name)
+		result.Author = jsonPerson(jsonFeed.Author)
 	}
 	if jsonFeed.Authors != nil {
 		result.Authors = jsonPersons(jsonFeed.Authors)
@@ -766,12 +1048,6 @@ func (t *DefaultJSONTranslator) Translate(feed interface{}) (*Feed, error) {
 		result.Items = append(result.Items, t.translateFeedItem(i))
 	}
 
-	// TODO UserComment is missing in global Feed
-	// TODO NextURL is missing in global Feed
-	// TODO Favicon is missing in global Feed
-	// TODO Exipred is missing in global Feed
-	// TODO Hubs is not supported in json.Feed
-	// TODO Extensions is not supported in json.Feed
 	return result, nil
 }
 
@@ -781,8 +1057,10 @@ func (t *DefaultJSONTranslator) translateFeedItem(jsonItem *json.Item) *Item {
 		Link:        jsonItem.URL,
 		Title:       jsonItem.Title,
 		Description: jsonItem.Summary,
+		ExternalURL: jsonItem.ExternalURL,
 		Published:   jsonItem.DatePublished,
 		Updated:     jsonItem.DateModified,
+		Extensions:  jsonItem.Extensions,
 	}
 
 	if jsonItem.URL != "" {
@@ -797,10 +1075,13 @@ func (t *DefaultJSONTranslator) translateFeedItem(jsonItem *json.Item) *Item {
 		item.Content = jsonItem.ContentText
 	}
 
+	if jsonItem.BannerImage != "" {
+		item.BannerImage = &Image{URL: jsonItem.BannerImage}
+	}
 	if jsonItem.Image != "" {
 		item.Image = &Image{URL: jsonItem.Image}
-	} else if jsonItem.BannerImage != "" {
-		item.Image = &Image{URL: jsonItem.BannerImage}
+	} else {
+		item.Image = item.BannerImage
 	}
 
 	if jsonItem.DatePublished != "" {
@@ -815,7 +1096,7 @@ func (t *DefaultJSONTranslator) translateFeedItem(jsonItem *json.Item) *Item {
 	}
 
 	if jsonItem.Author != nil {
-		item.Author = personFromText(jsonItem.Author.Name)
+		item.Author = jsonPerson(jsonItem.Author)
 	}
 	if jsonItem.Authors != nil {
 		item.Authors = jsonPersons(jsonItem.Authors)
@@ -830,30 +1111,258 @@ func (t *DefaultJSONTranslator) translateFeedItem(jsonItem *json.Item) *Item {
 	if jsonItem.Attachments != nil {
 		for _, attachment := range *jsonItem.Attachments {
 			e := &Enclosure{
-				URL:  attachment.URL,
-				Type: attachment.MimeType,
+				URL:      attachment.URL,
+				Type:     attachment.MimeType,
+				Title:    attachment.Title,
+				Duration: time.Duration(attachment.DurationInSeconds) * time.Second,
 			}
 			// RSS enclosure length is the size in bytes, not the duration.
 			if attachment.SizeInBytes > 0 {
 				e.Length = fmt.Sprintf("%d", attachment.SizeInBytes)
 			}
-			// Title is not defined in global enclosure
 			item.Enclosures = append(item.Enclosures, e)
 		}
 	}
 
-	// TODO ExternalURL is missing in global Feed
-	// TODO BannerImage is missing in global Feed
-	// Author.URL and Author.Avatar are missing in global feed
 	return item
 }
 
-// jsonPersons converts json feed authors to universal Persons, splitting
-// free-form "Name (email)" values.
+// jsonPersons converts json feed authors to universal Persons.
 func jsonPersons(authors []*json.Author) []*Person {
 	out := make([]*Person, 0, len(authors))
 	for _, a := range authors {
-		out = append(out, personFromText(a.Name))
+		out = append(out, jsonPerson(a))
 	}
 	return out
 }
+
+// jsonPerson converts a json feed author to a universal Person, splitting
+// a free-form "Name (email)" name.
+func jsonPerson(author *json.Author) *Person {
+	p := personFromText(author.Name)
+	p.URL = author.URL
+	p.Avatar = author.Avatar
+	return p
+}
+
+// DefaultHFeedTranslator converts an hfeed.Feed struct
+// into the generic Feed struct.
+//
+// This default implementation defines a set of
+// mapping rules between hfeed.Feed -> Feed
+// for each of the fields in Feed.
+type DefaultHFeedTranslator struct{}
+
+// Translate converts an h-feed into the universal
+// feed type.
+func (t *DefaultHFeedTranslator) Translate(feed interface{}) (*Feed, error) {
+	hf, found := feed.(*hfeed.Feed)
+	if !found {
+		return nil, fmt.Errorf("Feed did not match expected type of *hfeed.Feed")
+	}
+
+	result := &Feed{
+		Title:       hf.Name,
+		Link:        hf.URL,
+		Description: hf.Summary,
+		FeedType:    "hfeed",
+	}
+	if hf.URL != "" {
+		result.Links = []string{hf.URL}
+	}
+	if hf.Photo != "" {
+		result.Image = &Image{URL: hf.Photo}
+	}
+	if hf.Author != nil {
+		result.Author = hfeedPerson(hf.Author)
+		result.Authors = []*Person{result.Author}
+	}
+
+	result.Items = make([]*Item, 0, len(hf.Entries))
+	for _, entry := range hf.Entries {
+		result.Items = append(result.Items, t.translateFeedItem(entry))
+	}
+
+	// The feed-level times mirror the first (most recent) entry's, as an
+	// h-feed has none of its own.
+	if len(result.Items) > 0 {
+		result.Updated = result.Items[0].Updated
+		result.UpdatedParsed = result.Items[0].UpdatedParsed
+		result.Published = result.Items[0].Published
+		result.PublishedParsed = result.Items[0].PublishedParsed
+	}
+
+	return result, nil
+}
+
+func (t *DefaultHFeedTranslator) translateFeedItem(entry *hfeed.Entry) *Item {
+	item := &Item{
+		Title:           entry.Name,
+		Description:     entry.Summary,
+		Link:            entry.URL,
+		GUID:            entry.UID,
+		Published:       entry.Published,
+		PublishedParsed: entry.PublishedParsed,
+		Updated:         entry.Updated,
+		UpdatedParsed:   entry.UpdatedParsed,
+		Categories:      entry.Categories,
+	}
+	if item.GUID == "" {
+		item.GUID = entry.URL
+	}
+	if entry.URL != "" {
+		item.Links = []string{entry.URL}
+	}
+
+	if entry.Content != nil {
+		item.Content = entry.Content.HTML
+		if item.Content == "" {
+			item.Content = entry.Content.Value
+		}
+		// A note's name is implied from its content; it is not a title.
+		if strings.TrimSpace(item.Title) == strings.TrimSpace(entry.Content.Value) {
+			item.Title = ""
+		}
+	}
+
+	if len(entry.Photos) > 0 {
+		item.Image = &Image{URL: entry.Photos[0]}
+	}
+
+	for _, author := range entry.Authors {
+		item.Authors = append(item.Authors, hfeedPerson(author))
+	}
+	if len(item.Authors) > 0 {
+		item.Author = item.Authors[0]
+	}
+
+	return item
+}
+
+// hfeedPerson converts an h-card to a universal Person.
+func hfeedPerson(card *hfeed.Card) *Person {
+	return &Person{Name: card.Name, URL: card.URL, Avatar: card.Photo}
+}
+
+// DefaultActivityStreamsTranslator converts an activitystreams.Collection
+// struct into the generic Feed struct.
+//
+// This default implementation defines a set of
+// mapping rules between activitystreams.Collection -> Feed
+// for each of the fields in Feed.
+type DefaultActivityStreamsTranslator struct{}
+
+// Translate converts an ActivityStreams collection into the universal
+// feed type.
+func (t *DefaultActivityStreamsTranslator) Translate(feed interface{}) (*Feed, error) {
+	collection, found := feed.(*activitystreams.Collection)
+	if !found {
+		return nil, fmt.Errorf("Feed did not match expected type of *activitystreams.Collection")
+	}
+
+	result := &Feed{
+		Title:       collection.Name,
+		Description: collection.Summary,
+		FeedLink:    collection.ID,
+		FeedType:    "activitystreams",
+	}
+	if collection.PartOf != "" {
+		result.FeedLink = collection.PartOf
+	}
+
+	// A collection's items start on its first page, which is either embedded
+	// or followed as the next page; a page links its successor with next.
+	activities := collection.Items
+	switch {
+	case collection.FirstPage != nil:
+		activities = append(activities, collection.FirstPage.Items...)
+		result.NextURL = collection.FirstPage.Next
+	case collection.IsPage():
+		result.NextURL = collection.Next
+	case len(collection.Items) == 0:
+		result.NextURL = collection.First
+	}
+
+	result.Items = make([]*Item, 0, len(activities))
+	for _, activity := range activities {
+		result.Items = append(result.Items, t.translateFeedItem(activity))
+	}
+
+	// The feed-level times mirror the first (most recent) item's.
+	if len(result.Items) > 0 {
+		result.Published = result.Items[0].Published
+		result.PublishedParsed = result.Items[0].PublishedParsed
+		result.Updated = result.Items[0].Updated
+		result.UpdatedParsed = result.Items[0].UpdatedParsed
+	}
+
+	return result, nil
+}
+
+func (t *DefaultActivityStreamsTranslator) translateFeedItem(activity *activitystreams.Activity) *Item {
+	item := &Item{
+		GUID:            activity.ID,
+		Link:            activity.ID,
+		Published:       activity.Published,
+		PublishedParsed: activity.PublishedParsed,
+	}
+	author := activity.Actor
+
+	// The item is the activity's object: the post a Create published, or
+	// the one an Announce shared.
+	if object := activity.Object; object != nil {
+		if object.ID != "" {
+			item.GUID = object.ID
+			item.Link = object.ID
+		}
+		if object.URL != "" {
+			item.Link = object.URL
+		}
+		item.Title = object.Name
+		item.Description = object.Summary
+		item.Content = object.Content
+		if object.Published != "" {
+			item.Published = object.Published
+			item.PublishedParsed = object.PublishedParsed
+		}
+		item.Updated = object.Updated
+		item.UpdatedParsed = object.UpdatedParsed
+		if object.AttributedTo != "" {
+			author = object.AttributedTo
+		}
+		if object.InReplyTo != "" {
+			item.InReplyTo = []*InReplyTo{{Ref: object.InReplyTo, Href: object.InReplyTo}}
+		}
+
+		for _, tag := range object.Tags {
+			if tag.Type == "Hashtag" && tag.Name != "" {
+				item.Categories = append(item.Categories, strings.TrimPrefix(tag.Name, "#"))
+			}
+		}
+
+		for _, attachment := range object.Attachments {
+			if attachment.URL == "" {
+				continue
+			}
+			item.Enclosures = append(item.Enclosures, &Enclosure{
+				URL:   attachment.URL,
+				Type:  attachment.MediaType,
+				Title: attachment.Name,
+			})
+			if item.Image == nil && (attachment.Type == "Image" || strings.HasPrefix(attachment.MediaType, "image/")) {
+				item.Image = &Image{URL: attachment.URL, Title: attachment.Name}
+			}
+		}
+	}
+	if item.Link != "" {
+		item.Links = []string{item.Link}
+	}
+
+	// Actors are usually only given by IRI, which is all the Person gets.
+	if author != "" {
+		item.Author = &Person{URL: author}
+		item.Authors = []*Person{item.Author}
+	}
+
+	return item
+}
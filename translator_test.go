@@ -9,7 +9,9 @@ import (
 	"testing"
 
 	"github.com/mmcdole/gofeed"
+	"github.com/mmcdole/gofeed/activitystreams"
 	"github.com/mmcdole/gofeed/atom"
+	"github.com/mmcdole/gofeed/hfeed"
 	"github.com/mmcdole/gofeed/json"
 	"github.com/mmcdole/gofeed/rss"
 	"github.com/stretchr/testify/assert"
@@ -267,3 +269,108 @@ func TestDisableContentImageScan(t *testing.T) {
 	assert.Nil(t, out.Image)
 	assert.Nil(t, out.Items[0].Image)
 }
+
+func TestDefaultHFeedTranslator_Translate(t *testing.T) {
+	files, _ := filepath.Glob("testdata/translator/hfeed/*.html")
+	for _, f := range files {
+		base := filepath.Base(f)
+		name := strings.TrimSuffix(base, filepath.Ext(base))
+
+		fmt.Printf("Testing %s... ", name)
+
+		// Get actual source page
+		ff := fmt.Sprintf("testdata/translator/hfeed/%s.html", name)
+		f, _ := os.Open(ff)
+		defer f.Close()
+
+		// Parse actual page
+		translator := &gofeed.DefaultHFeedTranslator{}
+		fp := hfeed.Parser{}
+		hf, _ := fp.Parse(f)
+		actual, _ := translator.Translate(hf)
+
+		// Get json encoded expected feed result
+		ef := fmt.Sprintf("testdata/translator/hfeed/%s.json", name)
+		e, _ := os.ReadFile(ef)
+
+		// Unmarshal expected feed
+		expected := &gofeed.Feed{}
+		jsonEncoding.Unmarshal(e, &expected)
+
+		if assert.Equal(t, expected, actual, "Feed file %s.html did not match expected output %s.json", name, name) {
+			fmt.Printf("OK\n")
+		} else {
+			fmt.Printf("Failed\n")
+		}
+	}
+}
+
+func TestDefaultHFeedTranslator_Translate_WrongType(t *testing.T) {
+	translator := &gofeed.DefaultHFeedTranslator{}
+	hf, err := translator.Translate("wrong type")
+	assert.Nil(t, hf)
+	assert.NotNil(t, err)
+}
+
+// A note marked up as both p-name and e-content has no title of its own.
+func TestDefaultHFeedTranslator_Translate_NoteTitle(t *testing.T) {
+	page := `<html><body><div class="h-feed">
+		<div class="h-entry"><p class="p-name e-content">Just a note.</p></div>
+		<div class="h-entry"><h2 class="p-name">An Article</h2><div class="e-content">Body.</div></div>
+	</div></body></html>`
+	hf, err := (&hfeed.Parser{}).Parse(strings.NewReader(page))
+	if err != nil {
+		t.Fatal(err)
+	}
+	feed, err := (&gofeed.DefaultHFeedTranslator{}).Translate(hf)
+	assert.NoError(t, err)
+	assert.Equal(t, "", feed.Items[0].Title)
+	assert.Equal(t, "Just a note.", feed.Items[0].Content)
+	assert.Equal(t, "An Article", feed.Items[1].Title)
+}
+
+func TestDefaultActivityStreamsTranslator_Translate(t *testing.T) {
+	files, _ := filepath.Glob("testdata/translator/activitystreams/*.json")
+	for _, f := range files {
+		base := filepath.Base(f)
+		name := strings.TrimSuffix(base, filepath.Ext(base))
+
+		if strings.HasSuffix(name, "expected") {
+			continue
+		}
+
+		fmt.Printf("Testing %s... ", name)
+
+		// Get actual source collection
+		ff := fmt.Sprintf("testdata/translator/activitystreams/%s.json", name)
+		f, _ := os.Open(ff)
+		defer f.Close()
+
+		// Parse actual collection
+		translator := &gofeed.DefaultActivityStreamsTranslator{}
+		fp := activitystreams.Parser{}
+		collection, _ := fp.Parse(f)
+		actual, _ := translator.Translate(collection)
+
+		// Get json encoded expected feed result
+		ef := fmt.Sprintf("testdata/translator/activitystreams/%s_expected.json", name)
+		e, _ := os.ReadFile(ef)
+
+		// Unmarshal expected feed
+		expected := &gofeed.Feed{}
+		jsonEncoding.Unmarshal(e, &expected)
+
+		if assert.Equal(t, expected, actual, "Feed file %s.json did not match expected output %s_expected.json", name, name) {
+			fmt.Printf("OK\n")
+		} else {
+			fmt.Printf("Failed\n")
+		}
+	}
+}
+
+func TestDefaultActivityStreamsTranslator_Translate_WrongType(t *testing.T) {
+	translator := &gofeed.DefaultActivityStreamsTranslator{}
+	af, err := translator.Translate("wrong type")
+	assert.Nil(t, af)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,148 @@
+package websub
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"hash"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mmcdole/gofeed"
+)
+
+// maxContentSize bounds the content a hub may push in one request.
+const maxContentSize = 16 << 20
+
+// ServeHTTP answers the hub requests sent to a subscription's callback:
+// GET for verification of intent and denials, POST for content.
+func (s *Subscriber) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	id := r.URL.Query().Get("subscription")
+	switch r.Method {
+	case http.MethodGet:
+		s.verify(w, r, id)
+	case http.MethodPost:
+		s.receive(w, r, id)
+	default:
+		w.Header().Set("Allow", "GET, POST")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// verify answers a verification of intent with its challenge when it
+// matches a request the Subscriber made, and records denials.
+func (s *Subscriber) verify(w http.ResponseWriter, r *http.Request, id string) {
+	q := r.URL.Query()
+	mode := q.Get("hub.mode")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sub, ok := s.subs[id]
+	if !ok || q.Get("hub.topic") != sub.Topic {
+		http.NotFound(w, r)
+		return
+	}
+
+	switch {
+	case mode == "denied":
+		sub.State = StateDenied
+		sub.Reason = q.Get("hub.reason")
+		sub.pending = ""
+		w.WriteHeader(http.StatusOK)
+		return
+	case q.Get("hub.challenge") == "":
+		http.Error(w, "missing hub.challenge", http.StatusBadRequest)
+		return
+	// Hubs may also re-verify an active subscription of their own accord.
+	case mode == "subscribe" && (sub.pending == "subscribe" || (sub.pending == "" && sub.State == StateActive)):
+		sub.State = StateActive
+		sub.Expires = time.Time{}
+		if lease, err := strconv.Atoi(q.Get("hub.lease_seconds")); err == nil && lease > 0 {
+			sub.Expires = time.Now().Add(time.Duration(lease) * time.Second)
+		}
+	case mode == "unsubscribe" && sub.pending == "unsubscribe":
+		sub.State = StateUnsubscribed
+		sub.Expires = time.Time{}
+	default:
+		http.NotFound(w, r)
+		return
+	}
+
+	sub.pending = ""
+	w.Header().Set("Content-Type", "text/plain")
+	w.WriteHeader(http.StatusOK)
+	io.WriteString(w, q.Get("hub.challenge"))
+}
+
+// receive delivers content pushed to an active subscription. Content that
+// fails the signature check or does not parse is acknowledged all the
+// same, as WebSub requires, and reported to OnError.
+func (s *Subscriber) receive(w http.ResponseWriter, r *http.Request, id string) {
+	sub, ok := s.Subscription(id)
+	if !ok || sub.State != StateActive {
+		http.NotFound(w, r)
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxContentSize))
+	if err != nil {
+		http.Error(w, "content too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+
+	if sub.Secret != "" && !validSignature(r.Header.Get("X-Hub-Signature"), sub.Secret, body) {
+		s.reportError(sub, ErrInvalidSignature)
+		return
+	}
+
+	parser := s.Parser
+	if parser == nil {
+		parser = gofeed.NewParser()
+	}
+	feed, err := parser.Parse(bytes.NewReader(body))
+	if err != nil {
+		s.reportError(sub, err)
+		return
+	}
+	if s.OnFeed != nil {
+		s.OnFeed(sub, feed)
+	}
+}
+
+// validSignature checks an X-Hub-Signature header, "method=signature",
+// against the HMAC of body keyed with secret.
+func validSignature(header, secret string, body []byte) bool {
+	method, signature, ok := strings.Cut(header, "=")
+	if !ok {
+		return false
+	}
+
+	var h func() hash.Hash
+	switch strings.ToLower(method) {
+	case "sha1":
+		h = sha1.New
+	case "sha256":
+		h = sha256.New
+	case "sha384":
+		h = sha512.New384
+	case "sha512":
+		h = sha512.New
+	default:
+		return false
+	}
+
+	want, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(h, []byte(secret))
+	mac.Write(body)
+	return hmac.Equal(mac.Sum(nil), want)
+}
@@ -0,0 +1,392 @@
+package websub
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mmcdole/gofeed"
+)
+
+// DefaultRenewMargin is how long before a lease expires Run renews it when
+// Subscriber.RenewMargin is zero.
+const DefaultRenewMargin = time.Hour
+
+// DefaultRenewInterval is how often Run checks for leases to renew when it
+// is given no positive interval.
+const DefaultRenewInterval = time.Minute
+
+var (
+	// ErrNoHub is returned by Discover and SubscribeFeed for a feed that
+	// advertises no WebSub hub.
+	ErrNoHub = errors.New("websub: feed advertises no hub")
+	// ErrNoTopic is returned by Discover and SubscribeFeed for a feed
+	// without a self link to subscribe to.
+	ErrNoTopic = errors.New("websub: feed has no self link")
+	// ErrUnknownSubscription is returned for a subscription ID the
+	// Subscriber does not hold.
+	ErrUnknownSubscription = errors.New("websub: unknown subscription")
+	// ErrInvalidSignature is reported through Subscriber.OnError when
+	// pushed content does not carry a valid X-Hub-Signature. The content
+	// is dropped.
+	ErrInvalidSignature = errors.New("websub: invalid X-Hub-Signature")
+)
+
+// State is the lifecycle state of a subscription.
+type State int
+
+const (
+	// StatePending subscriptions wait for the hub to verify them.
+	StatePending State = iota
+	// StateActive subscriptions were verified and receive content.
+	StateActive
+	// StateDenied subscriptions were refused by the hub.
+	StateDenied
+	// StateUnsubscribed subscriptions were cancelled with Unsubscribe.
+	StateUnsubscribed
+)
+
+func (s State) String() string {
+	switch s {
+	case StatePending:
+		return "pending"
+	case StateActive:
+		return "active"
+	case StateDenied:
+		return "denied"
+	case StateUnsubscribed:
+		return "unsubscribed"
+	}
+	return "State(" + strconv.Itoa(int(s)) + ")"
+}
+
+// Subscription is a subscription to one topic at one hub. The Subscriber
+// hands out copies, so a Subscription is a snapshot of its state.
+type Subscription struct {
+	ID       string
+	Topic    string
+	Hub      string
+	Callback string
+	// Secret signs the content the hub pushes (hub.secret).
+	Secret string
+	State  State
+	// Expires is when the lease granted by the hub runs out, or the zero
+	// time when the hub granted no lease duration.
+	Expires time.Time
+	// Reason is the hub's reason for denying the subscription.
+	Reason string
+
+	// pending is the hub.mode awaiting verification, "" when none.
+	pending string
+}
+
+// Subscriber subscribes to WebSub hubs and is the http.Handler serving
+// their callbacks: it answers verification-of-intent requests and
+// delivers the content hubs push, parsed with Parser, to OnFeed.
+//
+// The Subscriber must be served at CallbackURL. Every subscription gets
+// its own callback, CallbackURL with a "subscription" query parameter.
+type Subscriber struct {
+	// CallbackURL is the public URL the Subscriber is served at.
+	CallbackURL string
+	// Client sends the subscription requests. Nil means
+	// http.DefaultClient.
+	Client *http.Client
+	// Parser parses pushed content. Nil means gofeed.NewParser().
+	Parser *gofeed.Parser
+	// LeaseSeconds is the lease duration requested from hubs. Zero leaves
+	// the choice to the hub.
+	LeaseSeconds int
+	// RenewMargin is how long before a lease expires Run renews it. Zero
+	// means DefaultRenewMargin.
+	RenewMargin time.Duration
+	// OnFeed is called with every feed pushed to an active subscription.
+	// It runs on the request's goroutine, so it should not block for
+	// long.
+	OnFeed func(sub Subscription, feed *gofeed.Feed)
+	// OnError is called with content that was dropped, because of an
+	// invalid signature or a parse error, and with failed renewals.
+	OnError func(sub Subscription, err error)
+
+	mu   sync.Mutex
+	subs map[string]*Subscription
+}
+
+// Discover returns the topic and the WebSub hubs a feed advertises: its
+// self link (Feed.FeedLink) and Feed.Hubs, read from rel="hub" links in
+// RSS and Atom and from JSON Feed hubs.
+func Discover(feed *gofeed.Feed) (topic string, hubs []string, err error) {
+	if len(feed.Hubs) == 0 {
+		return "", nil, ErrNoHub
+	}
+	if feed.FeedLink == "" {
+		return "", nil, ErrNoTopic
+	}
+	return feed.FeedLink, feed.Hubs, nil
+}
+
+// SubscribeFeed subscribes to feed's self link at the first hub it
+// advertises; see Discover.
+func (s *Subscriber) SubscribeFeed(ctx context.Context, feed *gofeed.Feed) (Subscription, error) {
+	topic, hubs, err := Discover(feed)
+	if err != nil {
+		return Subscription{}, err
+	}
+	return s.Subscribe(ctx, hubs[0], topic)
+}
+
+// Subscribe asks hub to push the updates of topic to the Subscriber. Hubs
+// verify the request by calling back, possibly before Subscribe returns;
+// the returned subscription is pending until they do.
+func (s *Subscriber) Subscribe(ctx context.Context, hub, topic string) (Subscription, error) {
+	id, err := randomHex(16)
+	if err != nil {
+		return Subscription{}, err
+	}
+	secret, err := randomHex(32)
+	if err != nil {
+		return Subscription{}, err
+	}
+	callback, err := s.callbackFor(id)
+	if err != nil {
+		return Subscription{}, err
+	}
+
+	sub := &Subscription{
+		ID:       id,
+		Topic:    topic,
+		Hub:      hub,
+		Callback: callback,
+		Secret:   secret,
+		State:    StatePending,
+		pending:  "subscribe",
+	}
+	s.mu.Lock()
+	if s.subs == nil {
+		s.subs = map[string]*Subscription{}
+	}
+	s.subs[id] = sub
+	s.mu.Unlock()
+
+	if err := s.request(ctx, sub, "subscribe"); err != nil {
+		s.mu.Lock()
+		delete(s.subs, id)
+		s.mu.Unlock()
+		return Subscription{}, err
+	}
+	return s.snapshot(id)
+}
+
+// Renew repeats the subscription request of the subscription id, asking
+// the hub for a new lease. The subscription stays active meanwhile.
+func (s *Subscriber) Renew(ctx context.Context, id string) error {
+	return s.send(ctx, id, "subscribe")
+}
+
+// Unsubscribe asks the hub to stop pushing the updates of the subscription
+// id. It is cancelled once the hub verifies the request.
+func (s *Subscriber) Unsubscribe(ctx context.Context, id string) error {
+	return s.send(ctx, id, "unsubscribe")
+}
+
+// RenewExpiring renews the active subscriptions whose lease runs out
+// before deadline. Failed renewals are reported to OnError and returned
+// together.
+func (s *Subscriber) RenewExpiring(ctx context.Context, deadline time.Time) error {
+	var expiring []string
+	s.mu.Lock()
+	for id, sub := range s.subs {
+		if sub.State == StateActive && !sub.Expires.IsZero() && sub.Expires.Before(deadline) {
+			expiring = append(expiring, id)
+		}
+	}
+	s.mu.Unlock()
+
+	var errs []error
+	for _, id := range expiring {
+		if err := s.Renew(ctx, id); err != nil {
+			if sub, ok := s.Subscription(id); ok {
+				s.reportError(sub, err)
+			}
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Run renews leases before they expire, checking every interval, or
+// DefaultRenewInterval when interval is not positive, until ctx is done.
+// Each check also removes the subscriptions that were denied or
+// unsubscribed, which are settled. It returns ctx.Err().
+func (s *Subscriber) Run(ctx context.Context, interval time.Duration) error {
+	margin := s.RenewMargin
+	if margin <= 0 {
+		margin = DefaultRenewMargin
+	}
+	if interval <= 0 {
+		interval = DefaultRenewInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case now := <-ticker.C:
+			s.removeSettled()
+			s.RenewExpiring(ctx, now.Add(margin))
+		}
+	}
+}
+
+// Remove forgets the subscription id, so that its callback answers the hub
+// with 404 Not Found from then on. The hub is not told; Unsubscribe an
+// active subscription first. Denied and unsubscribed subscriptions are
+// kept until they are removed, by Remove or by Run.
+func (s *Subscriber) Remove(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.subs, id)
+}
+
+// removeSettled removes the denied and unsubscribed subscriptions.
+func (s *Subscriber) removeSettled() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for id, sub := range s.subs {
+		if sub.State == StateDenied || sub.State == StateUnsubscribed {
+			delete(s.subs, id)
+		}
+	}
+}
+
+// Subscription returns the subscription id.
+func (s *Subscriber) Subscription(id string) (Subscription, bool) {
+	sub, err := s.snapshot(id)
+	return sub, err == nil
+}
+
+// Subscriptions returns every subscription the Subscriber holds.
+func (s *Subscriber) Subscriptions() []Subscription {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	subs := make([]Subscription, 0, len(s.subs))
+	for _, sub := range s.subs {
+		subs = append(subs, *sub)
+	}
+	return subs
+}
+
+func (s *Subscriber) snapshot(id string) (Subscription, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sub, ok := s.subs[id]
+	if !ok {
+		return Subscription{}, ErrUnknownSubscription
+	}
+	return *sub, nil
+}
+
+// send makes a subscription request in mode for an existing subscription.
+// The request is pending before it is sent, since the hub may verify it
+// before answering; when it fails, the subscription goes back to what it
+// awaited before, unless a verification has settled it meanwhile.
+func (s *Subscriber) send(ctx context.Context, id, mode string) error {
+	s.mu.Lock()
+	sub, ok := s.subs[id]
+	var prev string
+	if ok {
+		prev, sub.pending = sub.pending, mode
+	}
+	s.mu.Unlock()
+	if !ok {
+		return ErrUnknownSubscription
+	}
+
+	err := s.request(ctx, sub, mode)
+	if err != nil {
+		s.mu.Lock()
+		if sub.pending == mode {
+			sub.pending = prev
+		}
+		s.mu.Unlock()
+	}
+	return err
+}
+
+// request sends the hub a subscription request. Hubs answer 202 Accepted
+// and verify asynchronously, but any 2xx is taken as acceptance.
+func (s *Subscriber) request(ctx context.Context, sub *Subscription, mode string) error {
+	s.mu.Lock()
+	form := url.Values{
+		"hub.mode":     {mode},
+		"hub.topic":    {sub.Topic},
+		"hub.callback": {sub.Callback},
+	}
+	if mode == "subscribe" {
+		form.Set("hub.secret", sub.Secret)
+		if s.LeaseSeconds > 0 {
+			form.Set("hub.lease_seconds", strconv.Itoa(s.LeaseSeconds))
+		}
+	}
+	hub := sub.Hub
+	s.mu.Unlock()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, hub, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	client := s.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("websub: hub refused %s request: %s: %s", mode, resp.Status, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
+
+// callbackFor returns the callback URL of the subscription id.
+func (s *Subscriber) callbackFor(id string) (string, error) {
+	u, err := url.Parse(s.CallbackURL)
+	if err != nil {
+		return "", fmt.Errorf("websub: invalid CallbackURL: %w", err)
+	}
+	q := u.Query()
+	q.Set("subscription", id)
+	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
+
+func (s *Subscriber) reportError(sub Subscription, err error) {
+	if s.OnError != nil {
+		s.OnError(sub, err)
+	}
+}
+
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
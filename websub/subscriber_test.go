@@ -0,0 +1,344 @@
+package websub_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mmcdole/gofeed"
+	"github.com/mmcdole/gofeed/websub"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeHub is an in-process WebSub hub. It verifies subscription requests
+// before answering them, and pushes content signed with the subscriber's
+// secret.
+type fakeHub struct {
+	*httptest.Server
+	lease int
+	deny  bool
+
+	mu       sync.Mutex
+	requests []url.Values
+	verified []string // the hub.mode of each successful verification
+}
+
+func newFakeHub(t *testing.T, lease int) *fakeHub {
+	t.Helper()
+	hub := &fakeHub{lease: lease}
+	hub.Server = httptest.NewServer(http.HandlerFunc(hub.serve))
+	t.Cleanup(hub.Close)
+	return hub
+}
+
+func (h *fakeHub) serve(w http.ResponseWriter, r *http.Request) {
+	r.ParseForm()
+	h.mu.Lock()
+	h.requests = append(h.requests, r.PostForm)
+	h.mu.Unlock()
+
+	callback, _ := url.Parse(r.PostForm.Get("hub.callback"))
+	q := callback.Query()
+	q.Set("hub.topic", r.PostForm.Get("hub.topic"))
+	if h.deny {
+		q.Set("hub.mode", "denied")
+		q.Set("hub.reason", "not allowed")
+	} else {
+		q.Set("hub.mode", r.PostForm.Get("hub.mode"))
+		q.Set("hub.challenge", "challenge-"+r.PostForm.Get("hub.mode"))
+		q.Set("hub.lease_seconds", fmt.Sprint(h.lease))
+	}
+	callback.RawQuery = q.Encode()
+
+	resp, err := http.Get(callback.String())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if !h.deny && resp.StatusCode == http.StatusOK && string(body) == q.Get("hub.challenge") {
+		h.mu.Lock()
+		h.verified = append(h.verified, q.Get("hub.mode"))
+		h.mu.Unlock()
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// publish pushes content to a subscription's callback, signed with secret
+// unless it is empty.
+func (h *fakeHub) publish(t *testing.T, sub websub.Subscription, secret, content string) int {
+	t.Helper()
+	req, _ := http.NewRequest(http.MethodPost, sub.Callback, strings.NewReader(content))
+	req.Header.Set("Content-Type", "application/atom+xml")
+	if secret != "" {
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write([]byte(content))
+		req.Header.Set("X-Hub-Signature", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	return resp.StatusCode
+}
+
+// subscriber serves a Subscriber recording what it delivers.
+type subscriber struct {
+	*websub.Subscriber
+	mu     sync.Mutex
+	feeds  []*gofeed.Feed
+	errors []error
+}
+
+func newSubscriber(t *testing.T) *subscriber {
+	t.Helper()
+	s := &subscriber{Subscriber: &websub.Subscriber{LeaseSeconds: 600}}
+	s.OnFeed = func(sub websub.Subscription, feed *gofeed.Feed) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.feeds = append(s.feeds, feed)
+	}
+	s.OnError = func(sub websub.Subscription, err error) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.errors = append(s.errors, err)
+	}
+	server := httptest.NewServer(s.Subscriber)
+	t.Cleanup(server.Close)
+	s.CallbackURL = server.URL + "/websub?app=reader"
+	return s
+}
+
+const pushedFeed = `<feed xmlns="http://www.w3.org/2005/Atom"><title>pushed</title>
+	<entry><id>tag:example.org,2024:1</id><title>new</title></entry></feed>`
+
+func TestDiscover(t *testing.T) {
+	fp := gofeed.NewParser()
+	feed, _ := fp.ParseString(`<rss version="2.0" xmlns:atom="http://www.w3.org/2005/Atom"><channel>
+		<atom:link rel="self" href="http://example.org/feed"/>
+		<atom:link rel="hub" href="https://hub.example.org/"/></channel></rss>`)
+	topic, hubs, err := websub.Discover(feed)
+	assert.NoError(t, err)
+	assert.Equal(t, "http://example.org/feed", topic)
+	assert.Equal(t, []string{"https://hub.example.org/"}, hubs)
+
+	feed, _ = fp.ParseString(`{"version":"https://jsonfeed.org/version/1.1","feed_url":"http://example.org/feed.json",
+		"hubs":[{"type":"rssCloud","url":"https://cloud.example.org/"}],"items":[]}`)
+	_, _, err = websub.Discover(feed)
+	assert.Equal(t, websub.ErrNoHub, err)
+
+	feed, _ = fp.ParseString(`<feed xmlns="http://www.w3.org/2005/Atom"><link rel="hub" href="https://hub.example.org/"/></feed>`)
+	_, _, err = websub.Discover(feed)
+	assert.Equal(t, websub.ErrNoTopic, err)
+}
+
+func TestSubscriber_Lifecycle(t *testing.T) {
+	hub := newFakeHub(t, 600)
+	s := newSubscriber(t)
+	ctx := context.Background()
+
+	feed := &gofeed.Feed{FeedLink: "http://example.org/feed", Hubs: []string{hub.URL}}
+	sub, err := s.SubscribeFeed(ctx, feed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, websub.StateActive, sub.State)
+	assert.Equal(t, "http://example.org/feed", sub.Topic)
+	assert.WithinDuration(t, time.Now().Add(600*time.Second), sub.Expires, time.Minute)
+	assert.Contains(t, sub.Callback, "app=reader")
+
+	hub.mu.Lock()
+	assert.Equal(t, "subscribe", hub.requests[0].Get("hub.mode"))
+	assert.Equal(t, sub.Secret, hub.requests[0].Get("hub.secret"))
+	assert.Equal(t, "600", hub.requests[0].Get("hub.lease_seconds"))
+	hub.mu.Unlock()
+
+	// Signed content is parsed and delivered.
+	assert.Equal(t, http.StatusAccepted, hub.publish(t, sub, sub.Secret, pushedFeed))
+	// Content with a bad signature is acknowledged but dropped.
+	assert.Equal(t, http.StatusAccepted, hub.publish(t, sub, "wrong secret", pushedFeed))
+	assert.Equal(t, http.StatusAccepted, hub.publish(t, sub, "", pushedFeed))
+	s.mu.Lock()
+	if assert.Len(t, s.feeds, 1) {
+		assert.Equal(t, "pushed", s.feeds[0].Title)
+	}
+	assert.Equal(t, []error{websub.ErrInvalidSignature, websub.ErrInvalidSignature}, s.errors)
+	s.mu.Unlock()
+
+	// Leases expiring before the deadline are renewed.
+	assert.NoError(t, s.RenewExpiring(ctx, time.Now()))
+	assert.NoError(t, s.RenewExpiring(ctx, time.Now().Add(time.Hour)))
+	hub.mu.Lock()
+	assert.Len(t, hub.requests, 2)
+	assert.Equal(t, []string{"subscribe", "subscribe"}, hub.verified)
+	hub.mu.Unlock()
+
+	// Once unsubscribed, content is refused.
+	assert.NoError(t, s.Unsubscribe(ctx, sub.ID))
+	sub, _ = s.Subscription(sub.ID)
+	assert.Equal(t, websub.StateUnsubscribed, sub.State)
+	assert.Equal(t, http.StatusNotFound, hub.publish(t, sub, sub.Secret, pushedFeed))
+}
+
+func TestSubscriber_Denied(t *testing.T) {
+	hub := newFakeHub(t, 600)
+	hub.deny = true
+	s := newSubscriber(t)
+
+	sub, err := s.Subscribe(context.Background(), hub.URL, "http://example.org/feed")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, websub.StateDenied, sub.State)
+	assert.Equal(t, "not allowed", sub.Reason)
+	assert.Equal(t, http.StatusNotFound, hub.publish(t, sub, sub.Secret, pushedFeed))
+}
+
+func TestSubscriber_Verification(t *testing.T) {
+	s := newSubscriber(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted) // a hub that verifies later
+	}))
+	defer server.Close()
+
+	sub, err := s.Subscribe(context.Background(), server.URL, "http://example.org/feed")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, websub.StatePending, sub.State)
+
+	verify := func(mode, topic, challenge string) (int, string) {
+		u, _ := url.Parse(sub.Callback)
+		q := u.Query()
+		q.Set("hub.mode", mode)
+		q.Set("hub.topic", topic)
+		q.Set("hub.challenge", challenge)
+		u.RawQuery = q.Encode()
+		resp, err := http.Get(u.String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return resp.StatusCode, string(body)
+	}
+
+	// Requests the Subscriber did not make are not confirmed.
+	status, _ := verify("subscribe", "http://example.org/other", "c1")
+	assert.Equal(t, http.StatusNotFound, status)
+	status, _ = verify("unsubscribe", sub.Topic, "c2")
+	assert.Equal(t, http.StatusNotFound, status)
+
+	status, body := verify("subscribe", sub.Topic, "c3")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "c3", body)
+	sub, _ = s.Subscription(sub.ID)
+	assert.Equal(t, websub.StateActive, sub.State)
+	assert.True(t, sub.Expires.IsZero())
+}
+
+func TestSubscriber_HubRefuses(t *testing.T) {
+	s := newSubscriber(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "bad topic", http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	_, err := s.Subscribe(context.Background(), server.URL, "http://example.org/feed")
+	assert.ErrorContains(t, err, "bad topic")
+	assert.Empty(t, s.Subscriptions())
+}
+
+func TestSubscriber_HubFailsRequest(t *testing.T) {
+	s := newSubscriber(t)
+	var failing bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if failing {
+			http.Error(w, "try again later", http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted) // a hub that verifies later
+	}))
+	defer server.Close()
+
+	sub, err := s.Subscribe(context.Background(), server.URL, "http://example.org/feed")
+	if err != nil {
+		t.Fatal(err)
+	}
+	verify := func(mode string) int {
+		u, _ := url.Parse(sub.Callback)
+		q := u.Query()
+		q.Set("hub.mode", mode)
+		q.Set("hub.topic", sub.Topic)
+		q.Set("hub.challenge", "c")
+		u.RawQuery = q.Encode()
+		resp, err := http.Get(u.String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+	assert.Equal(t, http.StatusOK, verify("subscribe"))
+
+	// A failed unsubscribe leaves the subscription as it was: the hub
+	// may still verify its lease, and no unsubscribe is awaited.
+	failing = true
+	assert.ErrorContains(t, s.Unsubscribe(context.Background(), sub.ID), "try again later")
+	assert.Equal(t, http.StatusNotFound, verify("unsubscribe"))
+	assert.Equal(t, http.StatusOK, verify("subscribe"))
+
+	// So does a failed renewal.
+	assert.ErrorContains(t, s.Renew(context.Background(), sub.ID), "503")
+	assert.Equal(t, http.StatusOK, verify("subscribe"))
+	sub, _ = s.Subscription(sub.ID)
+	assert.Equal(t, websub.StateActive, sub.State)
+}
+
+// Run takes a non-positive interval for the default one, and drops settled
+// subscriptions as it goes; Remove drops any subscription.
+func TestSubscriber_RunRemovesSettled(t *testing.T) {
+	hub := newFakeHub(t, 600)
+	s := newSubscriber(t)
+	ctx := context.Background()
+
+	active, err := s.Subscribe(ctx, hub.URL, "http://example.org/active")
+	if err != nil {
+		t.Fatal(err)
+	}
+	hub.deny = true
+	denied, err := s.Subscribe(ctx, hub.URL, "http://example.org/denied")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, websub.StateDenied, denied.State)
+	hub.deny = false
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	assert.Equal(t, context.Canceled, s.Run(canceled, 0))
+
+	runCtx, stop := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer stop()
+	s.Run(runCtx, 5*time.Millisecond)
+	_, ok := s.Subscription(denied.ID)
+	assert.False(t, ok)
+	_, ok = s.Subscription(active.ID)
+	assert.True(t, ok)
+
+	s.Remove(active.ID)
+	assert.Empty(t, s.Subscriptions())
+	assert.Equal(t, http.StatusNotFound, hub.publish(t, active, active.Secret, pushedFeed))
+}